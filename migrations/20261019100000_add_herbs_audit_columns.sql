@@ -0,0 +1,54 @@
+-- +goose Up
+-- +goose StatementBegin
+-- legacy_timezone returns the zone in which the naive created_at values were
+-- written by DEFAULT NOW(): the server TimeZone, unless the operator names the
+-- zone the application sessions used in the herbs.legacy_timezone setting, e.g.
+-- PGOPTIONS='-c herbs.legacy_timezone=Europe/Moscow' goose up.
+-- Every migration that reads the naive values must interpret them in this zone.
+CREATE OR REPLACE FUNCTION legacy_timezone() RETURNS TEXT AS $$
+    SELECT COALESCE(NULLIF(current_setting('herbs.legacy_timezone', true), ''), current_setting('TimeZone'));
+$$ LANGUAGE sql STABLE;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE herbs
+    ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ADD COLUMN created_by VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN updated_by VARCHAR(255) NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DO $$
+BEGIN
+    RAISE NOTICE 'existing herbs.created_at values are interpreted in time zone %', legacy_timezone();
+END;
+$$;
+UPDATE herbs SET updated_at = created_at AT TIME ZONE legacy_timezone();
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION set_updated_at() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = NOW();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER herbs_set_updated_at
+    BEFORE UPDATE ON herbs
+    FOR EACH ROW
+    EXECUTE FUNCTION set_updated_at();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS herbs_set_updated_at ON herbs;
+DROP FUNCTION IF EXISTS set_updated_at();
+ALTER TABLE herbs
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS created_by,
+    DROP COLUMN IF EXISTS updated_by;
+DROP FUNCTION IF EXISTS legacy_timezone();
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Existing values were written by NOW() and hold the wall-clock time of the
+-- zone the writing sessions ran in. legacy_timezone() (see the audit columns
+-- migration) names that zone, so created_at and the updated_at backfill agree.
+DO $$
+BEGIN
+    RAISE NOTICE 'converting herbs.created_at from time zone %', legacy_timezone();
+END;
+$$;
+ALTER TABLE herbs
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ
+    USING created_at AT TIME ZONE legacy_timezone();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE herbs
+    ALTER COLUMN created_at TYPE TIMESTAMP WITHOUT TIME ZONE
+    USING created_at AT TIME ZONE legacy_timezone();
+-- +goose StatementEnd
@@ -0,0 +1,80 @@
+-- +goose Up
+-- +goose StatementBegin
+-- normalize_space collapses whitespace runs into single spaces and trims the ends.
+-- The class lists exactly the characters of Go's unicode.IsSpace, which
+-- models.NormalizeKey relies on through strings.Fields, so that both sides
+-- agree on non-ASCII whitespace such as NBSP.
+CREATE OR REPLACE FUNCTION normalize_space(s TEXT) RETURNS TEXT AS $$
+    SELECT BTRIM(REGEXP_REPLACE(COALESCE(s, ''),
+        '[\t\n\v\f\r \u0085\u00a0\u1680\u2000-\u200a\u2028\u2029\u202f\u205f\u3000]+', ' ', 'g'), ' ');
+$$ LANGUAGE sql IMMUTABLE;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- herb_identity_key must stay in sync with models.Herb.IdentityKey
+CREATE OR REPLACE FUNCTION herb_identity_key(name TEXT, latin_name TEXT) RETURNS TEXT AS $$
+    SELECT COALESCE(
+        'latin:' || NULLIF(LOWER(normalize_space(latin_name)), ''),
+        'name:' || LOWER(normalize_space(name))
+    );
+$$ LANGUAGE sql IMMUTABLE;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- merge_duplicate_herbs folds every group of herbs that share an identity key
+-- into its oldest record: empty fields of that record are filled from the
+-- duplicates, their region links and usages are moved to it, and the duplicates
+-- are deleted. Each merge is reported with a NOTICE. It handles the tables that
+-- reference herbs at this schema version and is meant for migrations only.
+CREATE OR REPLACE FUNCTION merge_duplicate_herbs() RETURNS VOID AS $$
+DECLARE
+    dup RECORD;
+BEGIN
+    FOR dup IN
+        SELECT id, keep_id
+        FROM (
+            SELECT id, FIRST_VALUE(id) OVER (
+                PARTITION BY herb_identity_key(name, latin_name)
+                ORDER BY created_at, id
+            ) AS keep_id
+            FROM herbs
+        ) grouped
+        WHERE id <> keep_id
+        ORDER BY keep_id, id
+    LOOP
+        RAISE NOTICE 'merging herb % into herb %', dup.id, dup.keep_id;
+
+        UPDATE herbs keep
+        SET description = COALESCE(NULLIF(keep.description, ''), d.description),
+            image_path = COALESCE(NULLIF(keep.image_path, ''), d.image_path),
+            is_poisonous = COALESCE(keep.is_poisonous, FALSE) OR COALESCE(d.is_poisonous, FALSE)
+        FROM herbs d
+        WHERE keep.id = dup.keep_id AND d.id = dup.id;
+
+        INSERT INTO herbs_regions (herb_id, region_id)
+        SELECT dup.keep_id, region_id FROM herbs_regions WHERE herb_id = dup.id
+        ON CONFLICT DO NOTHING;
+
+        UPDATE usages SET herb_id = dup.keep_id WHERE herb_id = dup.id;
+
+        DELETE FROM herbs WHERE id = dup.id;
+    END LOOP;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+SELECT merge_duplicate_herbs();
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE UNIQUE INDEX herbs_identity_key ON herbs (herb_identity_key(name, latin_name));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS herbs_identity_key;
+DROP FUNCTION IF EXISTS merge_duplicate_herbs();
+DROP FUNCTION IF EXISTS herb_identity_key(TEXT, TEXT);
+DROP FUNCTION IF EXISTS normalize_space(TEXT);
+-- +goose StatementEnd
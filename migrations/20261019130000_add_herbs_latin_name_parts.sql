@@ -0,0 +1,135 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE herbs
+    ADD COLUMN genus VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN species VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN infra_rank VARCHAR(16) NOT NULL DEFAULT '',
+    ADD COLUMN infra_epithet VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN authorship VARCHAR(255) NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- latin_rank returns the standard abbreviation of an infraspecific rank, or NULL
+-- when token is not a rank. It must stay in sync with ranks in package binomial.
+CREATE OR REPLACE FUNCTION latin_rank(token TEXT) RETURNS TEXT AS $$
+    SELECT CASE
+        WHEN LOWER(token) IN ('subsp.', 'subsp', 'ssp.', 'ssp') THEN 'subsp.'
+        WHEN LOWER(token) IN ('var.', 'var') THEN 'var.'
+        WHEN LOWER(token) IN ('subvar.', 'subvar') THEN 'subvar.'
+        WHEN LOWER(token) IN ('f.', 'fo.', 'forma') THEN 'f.'
+        WHEN LOWER(token) IN ('subf.', 'subf') THEN 'subf.'
+    END;
+$$ LANGUAGE sql IMMUTABLE;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- latin_name_key returns the lowercased latin name without the hybrid sign and
+-- author citations. It must stay in sync with binomial.Name.Key.
+CREATE OR REPLACE FUNCTION latin_name_key(latin_name TEXT) RETURNS TEXT AS $$
+DECLARE
+    tokens TEXT[] := STRING_TO_ARRAY(normalize_space(latin_name), ' ');
+    parts TEXT[];
+    i INT := 2;
+BEGIN
+    IF CARDINALITY(tokens) = 0 THEN
+        RETURN '';
+    END IF;
+    parts := ARRAY[LOWER(tokens[1])];
+
+    IF tokens[i] IN ('×', 'x', 'X') THEN
+        i := i + 1;
+    END IF;
+    IF tokens[i] ~ '^[[:alpha:]][[:alpha:]-]*$' AND latin_rank(tokens[i]) IS NULL THEN
+        parts := parts || LOWER(tokens[i]);
+        i := i + 1;
+    ELSIF tokens[i] IN ('sp.', 'spp.') THEN
+        parts := parts || tokens[i];
+        i := i + 1;
+    END IF;
+
+    -- Everything up to an infraspecific rank is the author citation of the species
+    WHILE i <= CARDINALITY(tokens) LOOP
+        IF latin_rank(tokens[i]) IS NOT NULL THEN
+            parts := parts || latin_rank(tokens[i]);
+            IF tokens[i + 1] ~ '^[[:alpha:]][[:alpha:]-]*$' THEN
+                parts := parts || LOWER(tokens[i + 1]);
+            END IF;
+            EXIT;
+        END IF;
+        i := i + 1;
+    END LOOP;
+
+    RETURN ARRAY_TO_STRING(parts, ' ');
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- Genus and species of existing records are filled here; the rank, infraspecific
+-- epithet and authorship are parsed by the application the next time a herb is saved.
+UPDATE herbs h
+SET genus = INITCAP(parts.genus),
+    species = CASE
+        WHEN parts.species ~ '^[[:alpha:]][[:alpha:]-]*$' AND latin_rank(parts.species) IS NULL
+        THEN LOWER(parts.species)
+        ELSE ''
+    END
+FROM (
+    SELECT id,
+           tokens[1] AS genus,
+           CASE WHEN tokens[2] IN ('×', 'x', 'X') THEN tokens[3] ELSE tokens[2] END AS species
+    FROM (
+        SELECT id, STRING_TO_ARRAY(normalize_space(latin_name), ' ') AS tokens
+        FROM herbs
+        WHERE normalize_space(latin_name) <> ''
+    ) split
+) parts
+WHERE h.id = parts.id;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX herbs_genus ON herbs (LOWER(genus));
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- The identity key no longer depends on the author citation, so the same taxon
+-- written by different authors is a duplicate. herb_identity_key must stay in
+-- sync with models.Herb.IdentityKey.
+DROP INDEX IF EXISTS herbs_identity_key;
+CREATE OR REPLACE FUNCTION herb_identity_key(name TEXT, latin_name TEXT) RETURNS TEXT AS $$
+    SELECT COALESCE(
+        'latin:' || NULLIF(latin_name_key(latin_name), ''),
+        'name:' || LOWER(normalize_space(name))
+    );
+$$ LANGUAGE sql IMMUTABLE;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- Herbs that only differed in the author citation now share an identity key
+SELECT merge_duplicate_herbs();
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE UNIQUE INDEX herbs_identity_key ON herbs (herb_identity_key(name, latin_name));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS herbs_identity_key;
+CREATE OR REPLACE FUNCTION herb_identity_key(name TEXT, latin_name TEXT) RETURNS TEXT AS $$
+    SELECT COALESCE(
+        'latin:' || NULLIF(LOWER(normalize_space(latin_name)), ''),
+        'name:' || LOWER(normalize_space(name))
+    );
+$$ LANGUAGE sql IMMUTABLE;
+CREATE UNIQUE INDEX herbs_identity_key ON herbs (herb_identity_key(name, latin_name));
+DROP FUNCTION IF EXISTS latin_name_key(TEXT);
+DROP FUNCTION IF EXISTS latin_rank(TEXT);
+DROP INDEX IF EXISTS herbs_genus;
+ALTER TABLE herbs
+    DROP COLUMN IF EXISTS authorship,
+    DROP COLUMN IF EXISTS infra_epithet,
+    DROP COLUMN IF EXISTS infra_rank,
+    DROP COLUMN IF EXISTS species,
+    DROP COLUMN IF EXISTS genus;
+-- +goose StatementEnd
@@ -0,0 +1,50 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE taxa (
+    id SERIAL PRIMARY KEY,
+    rank VARCHAR(16) NOT NULL CHECK (rank IN ('family', 'genus', 'species')),
+    name VARCHAR(255) NOT NULL,
+    parent_id INT REFERENCES taxa(id) ON DELETE SET NULL
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE UNIQUE INDEX taxa_rank_name ON taxa (rank, LOWER(name));
+CREATE INDEX taxa_parent_id ON taxa (parent_id);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE herbs ADD COLUMN taxon_id INT REFERENCES taxa(id) ON DELETE SET NULL;
+CREATE INDEX herbs_taxon_id ON herbs (taxon_id);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- Genera and species are taken from the parsed latin names; families are
+-- not known yet and are added with "taxonomy set".
+INSERT INTO taxa (rank, name)
+SELECT DISTINCT ON (LOWER(genus)) 'genus', genus
+FROM herbs
+WHERE genus <> ''
+ON CONFLICT DO NOTHING;
+
+INSERT INTO taxa (rank, name, parent_id)
+SELECT DISTINCT ON (LOWER(h.genus || ' ' || h.species)) 'species', h.genus || ' ' || h.species, g.id
+FROM herbs h
+JOIN taxa g ON g.rank = 'genus' AND LOWER(g.name) = LOWER(h.genus)
+WHERE h.species <> ''
+ON CONFLICT DO NOTHING;
+
+UPDATE herbs h
+SET taxon_id = COALESCE(
+    (SELECT id FROM taxa WHERE rank = 'species' AND h.species <> ''
+        AND LOWER(name) = LOWER(h.genus || ' ' || h.species)),
+    (SELECT id FROM taxa WHERE rank = 'genus' AND LOWER(name) = LOWER(h.genus)))
+WHERE h.genus <> '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS herbs_taxon_id;
+ALTER TABLE herbs DROP COLUMN IF EXISTS taxon_id;
+DROP TABLE IF EXISTS taxa;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE herb_names (
+    id SERIAL PRIMARY KEY,
+    herb_id INT NOT NULL REFERENCES herbs(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    language VARCHAR(8) NOT NULL,
+    type VARCHAR(16) NOT NULL DEFAULT 'vernacular' CHECK (type IN ('vernacular', 'synonym', 'accepted'))
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE UNIQUE INDEX herb_names_herb_language_name ON herb_names (herb_id, language, LOWER(name));
+CREATE INDEX herb_names_name ON herb_names (LOWER(name));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS herb_names;
+-- +goose StatementEnd
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Toxicity levels: 0 none, 1 mild, 2 moderate, 3 severe, 4 deadly
+ALTER TABLE herbs
+    ADD COLUMN toxicity_level SMALLINT NOT NULL DEFAULT 0 CHECK (toxicity_level BETWEEN 0 AND 4),
+    ADD COLUMN toxic_parts TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN toxic_compounds TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN toxicity_symptoms TEXT[] NOT NULL DEFAULT '{}';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- Herbs marked as poisonous are assumed severe until they are graded by hand
+UPDATE herbs SET toxicity_level = CASE WHEN is_poisonous THEN 3 ELSE 0 END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- is_poisonous is kept for existing queries and derived from the level
+ALTER TABLE herbs DROP COLUMN is_poisonous;
+ALTER TABLE herbs ADD COLUMN is_poisonous BOOLEAN GENERATED ALWAYS AS (toxicity_level >= 2) STORED;
+CREATE INDEX herbs_toxicity_level ON herbs (toxicity_level);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS herbs_toxicity_level;
+ALTER TABLE herbs DROP COLUMN is_poisonous;
+ALTER TABLE herbs ADD COLUMN is_poisonous BOOLEAN DEFAULT FALSE;
+UPDATE herbs SET is_poisonous = toxicity_level >= 2;
+ALTER TABLE herbs
+    DROP COLUMN IF EXISTS toxicity_symptoms,
+    DROP COLUMN IF EXISTS toxic_compounds,
+    DROP COLUMN IF EXISTS toxic_parts,
+    DROP COLUMN IF EXISTS toxicity_level;
+-- +goose StatementEnd
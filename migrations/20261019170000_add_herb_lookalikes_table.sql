@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The relation is symmetric: every pair is stored once with the smaller ID first
+CREATE TABLE herb_lookalikes (
+    herb_id INT NOT NULL REFERENCES herbs(id) ON DELETE CASCADE,
+    lookalike_id INT NOT NULL REFERENCES herbs(id) ON DELETE CASCADE,
+    notes TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (herb_id, lookalike_id),
+    CHECK (herb_id < lookalike_id)
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX herb_lookalikes_lookalike_id ON herb_lookalikes (lookalike_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS herb_lookalikes;
+-- +goose StatementEnd
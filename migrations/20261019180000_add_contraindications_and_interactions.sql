@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Severity: 1 minor, 2 moderate, 3 major
+CREATE TABLE contraindications (
+    id SERIAL PRIMARY KEY,
+    herb_id INT NOT NULL REFERENCES herbs(id) ON DELETE CASCADE,
+    condition VARCHAR(255) NOT NULL,
+    severity SMALLINT NOT NULL DEFAULT 2 CHECK (severity BETWEEN 1 AND 3),
+    notes TEXT NOT NULL DEFAULT ''
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE UNIQUE INDEX contraindications_herb_condition ON contraindications (herb_id, LOWER(condition));
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE interactions (
+    id SERIAL PRIMARY KEY,
+    herb_id INT NOT NULL REFERENCES herbs(id) ON DELETE CASCADE,
+    drug VARCHAR(255) NOT NULL,
+    severity SMALLINT NOT NULL DEFAULT 2 CHECK (severity BETWEEN 1 AND 3),
+    effect TEXT NOT NULL DEFAULT ''
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE UNIQUE INDEX interactions_herb_drug ON interactions (herb_id, LOWER(drug));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS interactions;
+DROP TABLE IF EXISTS contraindications;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Amounts are stored in the unit they were given in; the application converts
+-- between metric units. Empty strings and NULL mean "not specified".
+ALTER TABLE usages
+    ADD COLUMN plant_part VARCHAR(20) NOT NULL DEFAULT '',
+    ADD COLUMN preparation VARCHAR(20) NOT NULL DEFAULT '',
+    ADD COLUMN amount NUMERIC(10, 3) CHECK (amount > 0),
+    ADD COLUMN unit VARCHAR(10) NOT NULL DEFAULT '',
+    ADD COLUMN times_per_day SMALLINT CHECK (times_per_day BETWEEN 1 AND 24),
+    ADD COLUMN duration_days SMALLINT CHECK (duration_days > 0),
+    ADD CONSTRAINT usages_amount_unit CHECK ((amount IS NULL) = (unit = ''));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE usages
+    DROP CONSTRAINT IF EXISTS usages_amount_unit,
+    DROP COLUMN IF EXISTS duration_days,
+    DROP COLUMN IF EXISTS times_per_day,
+    DROP COLUMN IF EXISTS unit,
+    DROP COLUMN IF EXISTS amount,
+    DROP COLUMN IF EXISTS preparation,
+    DROP COLUMN IF EXISTS plant_part;
+-- +goose StatementEnd
@@ -0,0 +1,48 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE sources (
+    id SERIAL PRIMARY KEY,
+    kind VARCHAR(20) NOT NULL DEFAULT 'book' CHECK (kind IN ('book', 'article', 'website', 'other')),
+    title VARCHAR(500) NOT NULL,
+    authors TEXT[] NOT NULL DEFAULT '{}',
+    year SMALLINT,
+    container VARCHAR(255) NOT NULL DEFAULT '',
+    publisher VARCHAR(255) NOT NULL DEFAULT '',
+    volume VARCHAR(20) NOT NULL DEFAULT '',
+    issue VARCHAR(20) NOT NULL DEFAULT '',
+    pages VARCHAR(50) NOT NULL DEFAULT '',
+    doi VARCHAR(255) NOT NULL DEFAULT '',
+    isbn VARCHAR(13) NOT NULL DEFAULT '',
+    url VARCHAR(1000) NOT NULL DEFAULT ''
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE UNIQUE INDEX sources_doi ON sources (LOWER(doi)) WHERE doi <> '';
+CREATE UNIQUE INDEX sources_isbn ON sources (isbn) WHERE isbn <> '';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- A citation backs a fact about a herb with a source. The fact is the herb
+-- itself (its description), its toxicity, or one of its usages.
+CREATE TABLE citations (
+    id SERIAL PRIMARY KEY,
+    source_id INT NOT NULL REFERENCES sources(id) ON DELETE CASCADE,
+    herb_id INT NOT NULL REFERENCES herbs(id) ON DELETE CASCADE,
+    fact VARCHAR(20) NOT NULL DEFAULT 'herb' CHECK (fact IN ('herb', 'toxicity', 'usage')),
+    usage_id INT REFERENCES usages(id) ON DELETE CASCADE,
+    pages VARCHAR(50) NOT NULL DEFAULT '',
+    CONSTRAINT citations_usage CHECK ((fact = 'usage') = (usage_id IS NOT NULL))
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE UNIQUE INDEX citations_fact_source ON citations (herb_id, fact, COALESCE(usage_id, 0), source_id);
+CREATE INDEX citations_source ON citations (source_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS citations;
+DROP TABLE IF EXISTS sources;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Months are numbered 1-12; an empty array means the time is not known
+ALTER TABLE herbs
+    ADD COLUMN flowering_months SMALLINT[] NOT NULL DEFAULT '{}'
+        CHECK (flowering_months <@ '{1,2,3,4,5,6,7,8,9,10,11,12}'::SMALLINT[]),
+    ADD COLUMN harvest_months SMALLINT[] NOT NULL DEFAULT '{}'
+        CHECK (harvest_months <@ '{1,2,3,4,5,6,7,8,9,10,11,12}'::SMALLINT[]);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- Regional months override those of the herb; NULL means the herb's months apply
+ALTER TABLE herbs_regions
+    ADD COLUMN flowering_months SMALLINT[]
+        CHECK (flowering_months <@ '{1,2,3,4,5,6,7,8,9,10,11,12}'::SMALLINT[]),
+    ADD COLUMN harvest_months SMALLINT[]
+        CHECK (harvest_months <@ '{1,2,3,4,5,6,7,8,9,10,11,12}'::SMALLINT[]);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE herbs_regions
+    DROP COLUMN IF EXISTS harvest_months,
+    DROP COLUMN IF EXISTS flowering_months;
+ALTER TABLE herbs
+    DROP COLUMN IF EXISTS harvest_months,
+    DROP COLUMN IF EXISTS flowering_months;
+-- +goose StatementEnd
@@ -0,0 +1,53 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE regions
+    ADD COLUMN parent_id INT REFERENCES regions(id) ON DELETE SET NULL
+        CHECK (parent_id <> id);
+CREATE INDEX regions_parent_id ON regions (parent_id);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- Rejects a parent that is the region itself or one of its descendants.
+-- Changes of the hierarchy are serialized with a transaction-level advisory
+-- lock: otherwise two concurrent transactions nesting A in B and B in A would
+-- each miss the other's uncommitted change and together create a cycle. The
+-- ancestor walk runs after the lock is granted, so in READ COMMITTED it sees
+-- the hierarchy committed by the transaction that held the lock before; in a
+-- SERIALIZABLE unit of work the second transaction fails to serialize instead.
+CREATE OR REPLACE FUNCTION regions_check_cycle() RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.parent_id IS NULL THEN
+        RETURN NEW;
+    END IF;
+
+    PERFORM pg_advisory_xact_lock(hashtext('regions_hierarchy'));
+    IF EXISTS (
+        WITH RECURSIVE ancestors AS (
+            SELECT id, parent_id FROM regions WHERE id = NEW.parent_id
+            UNION
+            SELECT r.id, r.parent_id FROM regions r JOIN ancestors a ON r.id = a.parent_id
+        )
+        SELECT 1 FROM ancestors WHERE id = NEW.id
+    ) THEN
+        RAISE EXCEPTION 'region % cannot be nested in its own descendant %', NEW.id, NEW.parent_id
+            USING ERRCODE = 'check_violation', CONSTRAINT = 'regions_no_cycle';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER regions_check_cycle
+    BEFORE INSERT OR UPDATE OF parent_id ON regions
+    FOR EACH ROW
+    EXECUTE FUNCTION regions_check_cycle();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS regions_check_cycle ON regions;
+DROP FUNCTION IF EXISTS regions_check_cycle();
+DROP INDEX IF EXISTS regions_parent_id;
+ALTER TABLE regions DROP COLUMN IF EXISTS parent_id;
+-- +goose StatementEnd
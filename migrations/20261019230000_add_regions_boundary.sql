@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- GeoJSON Polygon or MultiPolygon with longitude/latitude coordinates;
+-- point lookups are done by the application, so PostGIS is not required
+ALTER TABLE regions ADD COLUMN boundary JSONB
+    CHECK (boundary IS NULL OR boundary->>'type' IN ('Polygon', 'MultiPolygon'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE regions DROP COLUMN IF EXISTS boundary;
+-- +goose StatementEnd
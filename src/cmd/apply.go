@@ -0,0 +1,307 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/gloowl/simple_crud/src/internal/database"
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+	"github.com/gloowl/simple_crud/src/internal/models"
+	"github.com/gloowl/simple_crud/src/internal/repository"
+
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+// applyHerbsCmd creates or updates herbs from a YAML file
+var applyHerbsCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Применить описание трав из YAML-файла",
+	Long: `Приводит базу данных в соответствие с YAML-файлом: создает отсутствующие травы
+и обновляет измененные. Травы сопоставляются по латинскому названию
+(или по названию, если латинское не указано) без учета регистра и лишних пробелов.
+Поля, отсутствующие в файле, не изменяются. Перед применением выводится план изменений.
+
+Формат файла:
+  herbs:
+    - name: Ромашка
+      latin_name: Matricaria chamomilla
+      description: Противовоспалительное средство
+      toxicity: none
+      flowering: 6-8
+      harvest: 6-8
+    - name: Белена
+      latin_name: Hyoscyamus niger
+      toxicity: severe
+      toxic_parts: [whole]
+      toxic_compounds: [атропин, скополамин]
+
+Поле is_poisonous из старых файлов по-прежнему принимается: true означает
+toxicity: severe, если степень не указана явно.`,
+	Example: `  herbs-cli herb apply -f herbs.yaml
+  herbs-cli herb apply -f herbs.yaml --dry-run
+  cat herbs.yaml | herbs-cli herb apply -f - --yes`,
+	RunE: applyHerbs,
+}
+
+// herbSpec is a herb as described in an apply file. Optional fields are
+// pointers so that fields missing from the file leave the stored value as is.
+type herbSpec struct {
+	Name        string  `yaml:"name"`
+	LatinName   string  `yaml:"latin_name"`
+	Description *string `yaml:"description"`
+	IsPoisonous *bool   `yaml:"is_poisonous"`
+	ImagePath   *string `yaml:"image_path"`
+
+	Toxicity         *models.ToxicityLevel `yaml:"toxicity"`
+	ToxicParts       []string              `yaml:"toxic_parts"`
+	ToxicCompounds   []string              `yaml:"toxic_compounds"`
+	ToxicitySymptoms []string              `yaml:"toxicity_symptoms"`
+
+	Flowering *string `yaml:"flowering"` // months in the form read by models.ParseMonths
+	Harvest   *string `yaml:"harvest"`
+}
+
+// applyFile is the top-level structure of an apply file
+type applyFile struct {
+	Herbs []herbSpec `yaml:"herbs"`
+}
+
+// applyAction is a planned change for a single herb
+type applyAction struct {
+	herb    *models.Herb
+	current *models.Herb // nil when the herb has to be created
+	changes []fieldChange
+}
+
+func init() {
+	herbCmd.AddCommand(applyHerbsCmd)
+
+	applyHerbsCmd.Flags().StringP("file", "f", "", "YAML-файл с травами (- для чтения из stdin)")
+	applyHerbsCmd.Flags().Bool("dry-run", false, "только показать план изменений")
+	applyHerbsCmd.Flags().BoolP("yes", "y", false, "применить без подтверждения")
+	applyHerbsCmd.MarkFlagRequired("file")
+}
+
+func applyHerbs(cmd *cobra.Command, args []string) error {
+	db := database.GetDB()
+	if db == nil {
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+	}
+	herbRepo := repository.NewHerbRepository(db)
+
+	path, _ := cmd.Flags().GetString("file")
+	specs, err := readApplyFile(path)
+	if err != nil {
+		return err
+	}
+
+	existing, err := herbRepo.GetAll()
+	if err != nil {
+		return i18n.Errorf("не удалось получить список трав: %v", err)
+	}
+
+	actions, err := planApply(specs, existing)
+	if err != nil {
+		return err
+	}
+
+	created, updated := printApplyPlan(actions)
+	if created == 0 && updated == 0 {
+		fmt.Println(i18n.T("Изменений нет."))
+		return nil
+	}
+
+	if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+		return nil
+	}
+
+	if yes, _ := cmd.Flags().GetBool("yes"); !yes {
+		fmt.Println()
+		ok, err := confirm(i18n.T("Применить изменения?"))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println(i18n.T("Применение отменено."))
+			return nil
+		}
+	}
+
+	// Either the whole file is applied or nothing is
+	err = repository.NewUnitOfWork(db).Do(func(repos *repository.Repositories) error {
+		for _, action := range actions {
+			switch {
+			case action.current == nil:
+				action.herb.CreatedBy = identity
+				if err := repos.Herbs.Create(action.herb); err != nil {
+					return i18n.Errorf("не удалось создать траву «%s»: %w", action.herb.Name, err)
+				}
+			case len(action.changes) > 0:
+				action.herb.UpdatedBy = identity
+				if err := repos.Herbs.Update(action.herb); err != nil {
+					return i18n.Errorf("не удалось обновить траву с ID %d: %w", action.herb.ID, err)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf(i18n.T("✅ Применено: создано %d, обновлено %d\n"), created, updated)
+	return nil
+}
+
+// readApplyFile parses herb specs from path; "-" reads standard input.
+// Both a top-level "herbs" key and a bare list of herbs are accepted.
+func readApplyFile(path string) ([]herbSpec, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, i18n.Errorf("не удалось прочитать файл: %v", err)
+	}
+
+	var file applyFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		var list []herbSpec
+		if listErr := yaml.Unmarshal(data, &list); listErr != nil {
+			return nil, i18n.Errorf("ошибка разбора YAML: %v", err)
+		}
+		file.Herbs = list
+	}
+
+	if len(file.Herbs) == 0 {
+		return nil, i18n.Errorf("в файле %s нет описаний трав", path)
+	}
+	return file.Herbs, nil
+}
+
+// planApply matches specs against the existing herbs by identity key and
+// works out which herbs have to be created or updated
+func planApply(specs []herbSpec, existing []models.Herb) ([]applyAction, error) {
+	byKey := make(map[string]*models.Herb, len(existing))
+	for i := range existing {
+		// Existing names saved before normalization must match their normalized spelling
+		normalized := existing[i]
+		normalized.NormalizeLatinName()
+		byKey[normalized.IdentityKey()] = &existing[i]
+	}
+
+	seen := make(map[string]int, len(specs))
+	actions := make([]applyAction, 0, len(specs))
+
+	for i, spec := range specs {
+		herb := &models.Herb{
+			Name:      strings.TrimSpace(spec.Name),
+			LatinName: strings.TrimSpace(spec.LatinName),
+		}
+		warnLatinName(herb)
+
+		key := herb.IdentityKey()
+		if first, ok := seen[key]; ok {
+			return nil, i18n.Errorf("запись #%d дублирует запись #%d (%s)", i+1, first, herb.Name)
+		}
+		seen[key] = i + 1
+
+		current := byKey[key]
+		if current != nil {
+			copied := *current
+			copied.Name, copied.LatinName = herb.Name, herb.LatinName
+			copied.NormalizeLatinName()
+			herb = &copied
+		}
+		if spec.Description != nil {
+			herb.Description = strings.TrimSpace(*spec.Description)
+		}
+		if spec.IsPoisonous != nil {
+			herb.SetPoisonous(*spec.IsPoisonous)
+		}
+		if spec.Toxicity != nil {
+			herb.SetToxicity(*spec.Toxicity)
+		}
+		if spec.ToxicParts != nil {
+			herb.ToxicParts = cleanList(spec.ToxicParts, true)
+		}
+		if spec.ToxicCompounds != nil {
+			herb.ToxicCompounds = cleanList(spec.ToxicCompounds, false)
+		}
+		if spec.ToxicitySymptoms != nil {
+			herb.ToxicitySymptoms = cleanList(spec.ToxicitySymptoms, false)
+		}
+		if spec.ImagePath != nil {
+			herb.ImagePath = strings.TrimSpace(*spec.ImagePath)
+		}
+		if spec.Flowering != nil {
+			months, err := models.ParseMonths(*spec.Flowering)
+			if err != nil {
+				return nil, i18n.Errorf("запись #%d (%s): %v", i+1, herb.Name, err)
+			}
+			herb.FloweringMonths = months
+		}
+		if spec.Harvest != nil {
+			months, err := models.ParseMonths(*spec.Harvest)
+			if err != nil {
+				return nil, i18n.Errorf("запись #%d (%s): %v", i+1, herb.Name, err)
+			}
+			herb.HarvestMonths = months
+		}
+
+		if err := herb.Validate(); err != nil {
+			return nil, i18n.Errorf("запись #%d (%s): %v", i+1, herb.Name, err)
+		}
+
+		action := applyAction{herb: herb, current: current}
+		if current != nil {
+			action.changes = diffHerbs(current, herb)
+		}
+		actions = append(actions, action)
+	}
+
+	return actions, nil
+}
+
+// printApplyPlan prints the planned changes and returns the number of herbs
+// that will be created and updated
+func printApplyPlan(actions []applyAction) (created, updated int) {
+	fmt.Println(i18n.T("План изменений:"))
+
+	unchanged := 0
+	for _, action := range actions {
+		switch {
+		case action.current == nil:
+			created++
+			fmt.Printf(i18n.T("  + создать    %s\n"), describeHerb(action.herb))
+		case len(action.changes) > 0:
+			updated++
+			fmt.Printf(i18n.T("  ~ обновить   ID %d %s\n"), action.current.ID, describeHerb(action.herb))
+			for _, change := range action.changes {
+				fmt.Printf("      %s\n", change)
+			}
+		default:
+			unchanged++
+			fmt.Printf(i18n.T("  = без изменений ID %d %s\n"), action.current.ID, describeHerb(action.herb))
+		}
+	}
+
+	fmt.Printf(i18n.T("\nСоздать: %d, обновить: %d, без изменений: %d\n"), created, updated, unchanged)
+	return created, updated
+}
+
+// describeHerb returns the herb name followed by its latin name, if any
+func describeHerb(herb *models.Herb) string {
+	if herb.LatinName == "" {
+		return herb.Name
+	}
+	return fmt.Sprintf("%s (%s)", herb.Name, herb.LatinName)
+}
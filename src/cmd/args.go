@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+	"github.com/gloowl/simple_crud/src/internal/models"
+)
+
+// maxIDRange limits how many IDs a single range like 1-100 may expand to
+const maxIDRange = 10000
+
+// parseIDList parses IDs given as separate arguments, comma-separated lists
+// and ranges like 5-9. The result is sorted and has no duplicates.
+func parseIDList(args []string) ([]int, error) {
+	seen := make(map[int]bool)
+
+	for _, arg := range args {
+		for _, part := range strings.Split(arg, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+
+			from, to, isRange := strings.Cut(part, "-")
+			first, err := strconv.Atoi(from)
+			if err != nil || first <= 0 {
+				return nil, i18n.Errorf("неверный ID: %s", part)
+			}
+			last := first
+			if isRange {
+				last, err = strconv.Atoi(to)
+				if err != nil || last < first {
+					return nil, i18n.Errorf("неверный диапазон ID: %s", part)
+				}
+				if last-first >= maxIDRange {
+					return nil, i18n.Errorf("слишком большой диапазон ID: %s (не более %d)", part, maxIDRange)
+				}
+			}
+
+			for id := first; id <= last; id++ {
+				seen[id] = true
+			}
+		}
+	}
+
+	ids := make([]int, 0, len(seen))
+	for id := range seen {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids, nil
+}
+
+// parseDate parses a date (2006-01-02) in the display timezone or an RFC 3339 timestamp
+func parseDate(value string) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", value, models.DisplayLocation()); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, i18n.Errorf("неверная дата: %s (ожидается ГГГГ-ММ-ДД или RFC 3339)", value)
+}
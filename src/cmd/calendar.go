@@ -0,0 +1,298 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gloowl/simple_crud/src/internal/database"
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+	"github.com/gloowl/simple_crud/src/internal/models"
+	"github.com/gloowl/simple_crud/src/internal/repository"
+	"github.com/gloowl/simple_crud/src/internal/table"
+
+	"github.com/spf13/cobra"
+)
+
+// calendarCmd shows when herbs flower and can be harvested
+var calendarCmd = &cobra.Command{
+	Use:   "calendar [HERB...]",
+	Short: "Календарь цветения и сбора трав",
+	Long: `Без аргументов выводит травы, которые можно собирать в месяце --month
+(по умолчанию в текущем), а с --flowering - травы, которые в этом месяце цветут.
+С --region выводятся только травы, растущие в регионе или в его подрегионах,
+и учитываются сроки цветения и сбора, указанные для этих регионов командой
+herb season.
+
+С аргументами (ID или названия трав) выводит таблицу на 12 месяцев:
+Ц - цветение, С - сбор.`,
+	Example: `  herbs-cli calendar --month 7 --region "Алтай"
+  herbs-cli calendar --month авг --flowering
+  herbs-cli calendar зверобой ромашка 12`,
+	RunE: showCalendar,
+}
+
+// seasonHerbCmd sets the months of a herb in a region
+var seasonHerbCmd = &cobra.Command{
+	Use:   "season HERB REGION",
+	Short: "Указать сроки цветения и сбора травы в регионе",
+	Long: `Привязывает траву к региону и задает сроки цветения и сбора в этом регионе.
+Если флаг не указан, сохраняются сроки, уже заданные для региона, а если
+их нет - действуют сроки, указанные для самой травы. Значение inherit удаляет
+сроки, заданные для региона, и для него снова действуют сроки травы.`,
+	Args: cobra.ExactArgs(2),
+	Example: `  herbs-cli herb season 1 "Алтай" --flowering 7-8 --harvest 7-8
+  herbs-cli herb season 1 "Краснодарский край" --harvest 6
+  herbs-cli herb season 1 "Алтай" --flowering inherit`,
+	RunE: setHerbSeason,
+}
+
+func init() {
+	rootCmd.AddCommand(calendarCmd)
+	herbCmd.AddCommand(seasonHerbCmd)
+
+	calendarCmd.Flags().String("month", "", "месяц: номер или название (по умолчанию текущий)")
+	calendarCmd.Flags().String("region", "", "регион (ID или название) вместе с его подрегионами")
+	calendarCmd.Flags().Bool("flowering", false, "показать цветущие травы вместо трав для сбора")
+	calendarCmd.Flags().StringP("output", "o", "text", "формат вывода (text, json)")
+
+	addSeasonFlags(seasonHerbCmd)
+}
+
+// seasonMark returns the mark of a month in the calendar grid
+func seasonMark(season models.HerbSeason, month int) string {
+	mark := ""
+	if season.Flowering.Contains(month) {
+		mark += i18n.T("Ц")
+	}
+	if season.Harvest.Contains(month) {
+		mark += i18n.T("С")
+	}
+	return mark
+}
+
+func showCalendar(cmd *cobra.Command, args []string) error {
+	db := database.GetDB()
+	if db == nil {
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+	}
+	repos := repository.NewRepositories(db)
+
+	format, _ := cmd.Flags().GetString("output")
+	if format != "" && format != "text" && format != "json" {
+		return i18n.Errorf("неизвестный формат вывода: %s (доступно: text, json)", format)
+	}
+
+	var regionIDs []int
+	regionName, _ := cmd.Flags().GetString("region")
+	if regionName = strings.TrimSpace(regionName); regionName != "" {
+		region, err := resolveRegion(repos.Regions, regionName)
+		if err != nil {
+			return err
+		}
+		if regionIDs, err = repos.Regions.Subtree(region.ID); err != nil {
+			return err
+		}
+		regionName = region.Name
+	}
+
+	var seasons []models.HerbSeason
+	if len(args) == 0 {
+		month := int(time.Now().Month())
+		if value, _ := cmd.Flags().GetString("month"); value != "" {
+			var err error
+			if month, err = models.ParseMonth(value); err != nil {
+				return err
+			}
+		}
+		flowering, _ := cmd.Flags().GetBool("flowering")
+
+		var err error
+		if seasons, err = repos.Calendar.InSeason(month, !flowering, regionIDs); err != nil {
+			return err
+		}
+		if format == "json" {
+			return writeSeasonsJSON(seasons)
+		}
+		printInSeason(seasons, month, flowering, regionName)
+		return nil
+	}
+
+	ids := make([]int, 0, len(args))
+	for _, arg := range args {
+		herb, err := resolveHerb(repos.Herbs, arg)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, herb.ID)
+	}
+
+	seasons, err := repos.Calendar.Seasons(ids, regionIDs)
+	if err != nil {
+		return err
+	}
+	if format == "json" {
+		return writeSeasonsJSON(seasons)
+	}
+	return printCalendarGrid(seasons)
+}
+
+// printInSeason prints the herbs that flower or can be harvested in month
+func printInSeason(seasons []models.HerbSeason, month int, flowering bool, region string) {
+	when := models.MonthLabel(month)
+	if region != "" {
+		when += ", " + region
+	}
+
+	if len(seasons) == 0 {
+		if flowering {
+			fmt.Printf(i18n.T("Цветущие травы не найдены (%s)\n"), when)
+		} else {
+			fmt.Printf(i18n.T("Травы для сбора не найдены (%s)\n"), when)
+		}
+		return
+	}
+
+	if flowering {
+		fmt.Printf(i18n.T("Цветут (%s):\n"), when)
+	} else {
+		fmt.Printf(i18n.T("Можно собирать (%s):\n"), when)
+	}
+	for _, season := range seasons {
+		line := fmt.Sprintf("  [%d] %s", season.Herb.ID, season.Herb.Name)
+		if season.Herb.LatinName != "" {
+			line += " (" + season.Herb.LatinName + ")"
+		}
+		months := season.Harvest
+		if flowering {
+			months = season.Flowering
+		}
+		if season.Region != "" && season.Region != region {
+			line += " — " + season.Region
+		}
+		fmt.Printf("%s: %s\n", line, months.Label())
+	}
+}
+
+// printCalendarGrid prints the months of flowering and harvest of herbs as a table
+func printCalendarGrid(seasons []models.HerbSeason) error {
+	if len(seasons) == 0 {
+		fmt.Println(i18n.T("Травы не найдены"))
+		return nil
+	}
+
+	withRegions := false
+	for _, season := range seasons {
+		withRegions = withRegions || season.Region != ""
+	}
+
+	t := table.Table{MaxWidth: terminalWidth()}
+	t.Columns = append(t.Columns, table.Column{Title: i18n.T("Трава"), MaxWidth: 30})
+	if withRegions {
+		t.Columns = append(t.Columns, table.Column{Title: i18n.T("Регион"), MaxWidth: 25})
+	}
+	for month := 1; month <= 12; month++ {
+		t.Columns = append(t.Columns, table.Column{Title: models.MonthLabel(month)})
+	}
+
+	for _, season := range seasons {
+		row := []string{season.Herb.Name}
+		if withRegions {
+			row = append(row, season.Region)
+		}
+		for month := 1; month <= 12; month++ {
+			row = append(row, seasonMark(season, month))
+		}
+		t.AddRow(row...)
+	}
+
+	if err := t.Render(os.Stdout); err != nil {
+		return err
+	}
+	fmt.Println(i18n.T("\nЦ - цветение, С - сбор"))
+	return nil
+}
+
+func writeSeasonsJSON(seasons []models.HerbSeason) error {
+	if seasons == nil {
+		seasons = []models.HerbSeason{}
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(seasons)
+}
+
+func setHerbSeason(cmd *cobra.Command, args []string) error {
+	db := database.GetDB()
+	if db == nil {
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+	}
+	repos := repository.NewRepositories(db)
+
+	herb, err := resolveHerb(repos.Herbs, args[0])
+	if err != nil {
+		return err
+	}
+	region, err := resolveRegion(repos.Regions, args[1])
+	if err != nil {
+		return err
+	}
+
+	flowering, inheritFlowering, err := regionSeasonFlag(cmd, "flowering")
+	if err != nil {
+		return err
+	}
+	harvest, inheritHarvest, err := regionSeasonFlag(cmd, "harvest")
+	if err != nil {
+		return err
+	}
+
+	link := &models.HerbRegion{
+		HerbID:          herb.ID,
+		RegionID:        region.ID,
+		FloweringMonths: flowering,
+		HarvestMonths:   harvest,
+	}
+	if err := repos.Regions.SetHerbSeason(link, inheritFlowering, inheritHarvest); err != nil {
+		return err
+	}
+
+	fmt.Printf(i18n.T("✅ Сроки травы «%s» в регионе «%s» сохранены\n"), herb.Name, region.Name)
+	flowering, harvest = link.FloweringMonths, link.HarvestMonths
+	if flowering == nil {
+		flowering = herb.FloweringMonths
+	}
+	if harvest == nil {
+		harvest = herb.HarvestMonths
+	}
+	fmt.Printf(i18n.T("  Цветение: %s\n  Сбор: %s\n"), monthsOrDash(flowering), monthsOrDash(harvest))
+	return nil
+}
+
+// inheritMonths is the value of a season flag of herb season that removes the
+// months set for the region, so that the months of the herb apply again
+const inheritMonths = "inherit"
+
+// regionSeasonFlag parses a season flag of herb season. The months are nil when
+// the flag was not given or is inheritMonths.
+func regionSeasonFlag(cmd *cobra.Command, name string) (months models.Months, inherit bool, err error) {
+	if !cmd.Flags().Changed(name) {
+		return nil, false, nil
+	}
+	value, _ := cmd.Flags().GetString(name)
+	if strings.EqualFold(strings.TrimSpace(value), inheritMonths) {
+		return nil, true, nil
+	}
+	months, err = models.ParseMonths(value)
+	return months, false, err
+}
+
+// monthsOrDash returns the translated months or a dash when there are none
+func monthsOrDash(months models.Months) string {
+	if len(months) == 0 {
+		return "—"
+	}
+	return months.Label()
+}
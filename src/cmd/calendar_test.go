@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gloowl/simple_crud/src/internal/models"
+
+	"github.com/spf13/cobra"
+)
+
+func TestRegionSeasonFlag(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		wantMonths  models.Months
+		wantInherit bool
+		wantErr     bool
+	}{
+		{"not given", nil, nil, false, false},
+		{"months", []string{"--flowering", "6-8"}, models.Months{6, 7, 8}, false, false},
+		{"inherit", []string{"--flowering", "inherit"}, nil, true, false},
+		{"inherit in any case", []string{"--flowering", " Inherit "}, nil, true, false},
+		{"invalid", []string{"--flowering", "13"}, nil, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			addSeasonFlags(cmd)
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatal(err)
+			}
+
+			months, inherit, err := regionSeasonFlag(cmd, "flowering")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("regionSeasonFlag() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(months, tt.wantMonths) || inherit != tt.wantInherit {
+				t.Errorf("regionSeasonFlag() = %v, %v, want %v, %v", months, inherit, tt.wantMonths, tt.wantInherit)
+			}
+		})
+	}
+}
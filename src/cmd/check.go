@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gloowl/simple_crud/src/internal/database"
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+	"github.com/gloowl/simple_crud/src/internal/models"
+	"github.com/gloowl/simple_crud/src/internal/repository"
+
+	"github.com/spf13/cobra"
+)
+
+// checkCmd checks a set of herbs against conditions and medications
+var checkCmd = &cobra.Command{
+	Use:   "check HERB...",
+	Short: "Проверить безопасность сочетания трав",
+	Long: `Проверяет травы (по ID или названию) и сообщает обо всех найденных проблемах,
+начиная с самых серьезных: ядовитость трав, противопоказания и взаимодействия
+с лекарствами. Слабо токсичные травы отмечаются как незначительная проблема.
+
+С --condition учитываются только противопоказания при указанных состояниях,
+с --drug - только взаимодействия с указанными лекарствами или группами лекарств;
+без этих флагов выводятся все противопоказания и взаимодействия трав.
+Состояние или лекарство совпадает с записью в базе, если одно из названий
+содержит другое (без учета регистра). Состояния и лекарства, о которых в базе
+нет ни одной записи, перечисляются отдельно как непроверенные.`,
+	Args: cobra.MinimumNArgs(1),
+	Example: `  herbs-cli check зверобой валериана
+  herbs-cli check 7 12 --condition беременность --drug варфарин,антидепрессанты`,
+	RunE: checkHerbs,
+}
+
+func init() {
+	rootCmd.AddCommand(checkCmd)
+
+	checkCmd.Flags().StringSlice("condition", nil, "состояния через запятую: беременность, дети, заболевания")
+	checkCmd.Flags().StringSlice("drug", nil, "лекарства или группы лекарств через запятую")
+}
+
+// safetyFinding is a single problem found by check
+type safetyFinding struct {
+	Severity models.Severity
+	Herb     string
+	Message  string
+}
+
+// resolveHerb finds a herb by ID or by name. A name must match a single herb
+// exactly (name or latin name) or be the only partial match.
+func resolveHerb(repo *repository.HerbRepository, arg string) (*models.Herb, error) {
+	if id, err := strconv.Atoi(arg); err == nil {
+		return repo.GetByID(id)
+	}
+
+	herbs, err := repo.Search(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	var exact []models.Herb
+	for _, herb := range herbs {
+		if strings.EqualFold(herb.Name, arg) || strings.EqualFold(herb.LatinName, arg) {
+			exact = append(exact, herb)
+		}
+	}
+	if len(exact) == 1 {
+		return &exact[0], nil
+	}
+	if len(exact) == 0 && len(herbs) == 1 {
+		return &herbs[0], nil
+	}
+	if len(herbs) == 0 {
+		return nil, i18n.Errorf("трава «%s» не найдена", arg)
+	}
+
+	candidates := herbs
+	if len(exact) > 0 {
+		candidates = exact
+	}
+	names := make([]string, len(candidates))
+	for i, herb := range candidates {
+		names[i] = fmt.Sprintf("%s [%d]", herb.Name, herb.ID)
+	}
+	return nil, i18n.Errorf("под «%s» подходит несколько трав: %s; укажите ID", arg, strings.Join(names, ", "))
+}
+
+func checkHerbs(cmd *cobra.Command, args []string) error {
+	db := database.GetDB()
+	if db == nil {
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+	}
+	repos := repository.NewRepositories(db)
+
+	conditions, _ := cmd.Flags().GetStringSlice("condition")
+	drugs, _ := cmd.Flags().GetStringSlice("drug")
+	conditions, drugs = cleanList(conditions, false), cleanList(drugs, false)
+
+	var (
+		herbs    []*models.Herb
+		ids      []int
+		seen     = make(map[int]bool)
+		findings []safetyFinding
+	)
+	for _, arg := range args {
+		herb, err := resolveHerb(repos.Herbs, strings.TrimSpace(arg))
+		if err != nil {
+			return err
+		}
+		if seen[herb.ID] {
+			continue
+		}
+		seen[herb.ID] = true
+		herbs = append(herbs, herb)
+		ids = append(ids, herb.ID)
+
+		switch {
+		case herb.Toxicity >= models.PoisonousLevel:
+			findings = append(findings, safetyFinding{
+				Severity: models.SeverityOfToxicity(herb.Toxicity),
+				Herb:     herb.Name,
+				Message:  fmt.Sprintf(i18n.T("ядовита, токсичность: %s"), herb.ToxicityLabel()),
+			})
+		case herb.Toxicity > models.ToxicityNone:
+			// Mild toxicity does not make a herb poisonous, but is still worth a note
+			findings = append(findings, safetyFinding{
+				Severity: models.SeverityMinor,
+				Herb:     herb.Name,
+				Message:  fmt.Sprintf(i18n.T("слабо токсична: %s"), herb.ToxicityLabel()),
+			})
+		}
+	}
+
+	contraindications, err := repos.Contraindications.Find(ids, conditions)
+	if err != nil {
+		return err
+	}
+	for _, c := range contraindications {
+		findings = append(findings, safetyFinding{
+			Severity: c.Severity,
+			Herb:     c.HerbName,
+			Message:  fmt.Sprintf(i18n.T("противопоказание: %s"), c.Condition) + withNote(c.Notes),
+		})
+	}
+
+	interactions, err := repos.Interactions.Find(ids, drugs)
+	if err != nil {
+		return err
+	}
+	for _, i := range interactions {
+		findings = append(findings, safetyFinding{
+			Severity: i.Severity,
+			Herb:     i.HerbName,
+			Message:  fmt.Sprintf(i18n.T("взаимодействие с «%s»"), i.Drug) + withNote(i.Effect),
+		})
+	}
+
+	unknownConditions, err := repos.Contraindications.Unknown(conditions)
+	if err != nil {
+		return err
+	}
+	unknownDrugs, err := repos.Interactions.Unknown(drugs)
+	if err != nil {
+		return err
+	}
+
+	sort.SliceStable(findings, func(a, b int) bool {
+		return findings[a].Severity > findings[b].Severity
+	})
+
+	names := make([]string, len(herbs))
+	for i, herb := range herbs {
+		names[i] = herb.Name
+	}
+	fmt.Printf(i18n.T("Травы: %s\n"), strings.Join(names, ", "))
+	if len(conditions) > 0 {
+		fmt.Printf(i18n.T("Состояния: %s\n"), strings.Join(conditions, ", "))
+	}
+	if len(drugs) > 0 {
+		fmt.Printf(i18n.T("Лекарства: %s\n"), strings.Join(drugs, ", "))
+	}
+	fmt.Println()
+
+	if len(unknownConditions) > 0 {
+		fmt.Printf(i18n.T("❓ Нет сведений о противопоказаниях при: %s - не проверено\n"), strings.Join(unknownConditions, ", "))
+	}
+	if len(unknownDrugs) > 0 {
+		fmt.Printf(i18n.T("❓ Нет сведений о взаимодействиях с: %s - не проверено\n"), strings.Join(unknownDrugs, ", "))
+	}
+	unknown := len(unknownConditions)+len(unknownDrugs) > 0
+
+	if len(findings) == 0 {
+		if unknown {
+			fmt.Println(i18n.T("Известных проблем не найдено, но не все состояния и лекарства удалось проверить"))
+		} else {
+			fmt.Println(i18n.T("✅ Известных проблем не найдено"))
+		}
+		return nil
+	}
+	if unknown {
+		fmt.Println()
+	}
+
+	major := 0
+	for _, finding := range findings {
+		if finding.Severity == models.SeverityMajor {
+			major++
+		}
+		fmt.Printf("[%s] %s: %s\n", finding.Severity.Label(), finding.Herb, finding.Message)
+	}
+
+	fmt.Printf(i18n.T("\n⚠️  Найдено проблем: %d, из них серьезных: %d\n"), len(findings), major)
+	return nil
+}
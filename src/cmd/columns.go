@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+	"github.com/gloowl/simple_crud/src/internal/models"
+	"github.com/gloowl/simple_crud/src/internal/table"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// herbColumn is a column that can be selected with --columns
+type herbColumn struct {
+	Key    string
+	Column table.Column
+	Value  func(h *models.Herb) string
+}
+
+// herbColumns lists the available table columns in their documented order
+var herbColumns = []herbColumn{
+	{"id", table.Column{Title: "ID", AlignRight: true}, func(h *models.Herb) string { return strconv.Itoa(h.ID) }},
+	{"name", table.Column{Title: "Название", MaxWidth: 30}, func(h *models.Herb) string { return h.Name }},
+	{"latin", table.Column{Title: "Латинское название", MaxWidth: 35}, func(h *models.Herb) string { return h.LatinName }},
+	{"genus", table.Column{Title: "Род", MaxWidth: 25}, func(h *models.Herb) string { return h.Genus }},
+	{"species", table.Column{Title: "Вид", MaxWidth: 25}, func(h *models.Herb) string { return h.Species }},
+	{"desc", table.Column{Title: "Описание", MaxWidth: 60}, func(h *models.Herb) string { return h.Description }},
+	{"poisonous", table.Column{Title: "Ядовито"}, func(h *models.Herb) string { return h.PoisonousLabel() }},
+	{"toxicity", table.Column{Title: "Токсичность"}, func(h *models.Herb) string { return h.ToxicityLabel() }},
+	{"flowering", table.Column{Title: "Цветение", MaxWidth: 30}, func(h *models.Herb) string { return h.FloweringMonths.Label() }},
+	{"harvest", table.Column{Title: "Сбор", MaxWidth: 30}, func(h *models.Herb) string { return h.HarvestMonths.Label() }},
+	{"image", table.Column{Title: "Изображение", MaxWidth: 40}, func(h *models.Herb) string { return h.ImagePath }},
+	{"created", table.Column{Title: "Создано"}, func(h *models.Herb) string { return formatDate(h.CreatedAt) }},
+	{"updated", table.Column{Title: "Обновлено"}, func(h *models.Herb) string { return formatDate(h.UpdatedAt) }},
+	{"created_by", table.Column{Title: "Создал", MaxWidth: 20}, func(h *models.Herb) string { return h.CreatedBy }},
+	{"updated_by", table.Column{Title: "Изменил", MaxWidth: 20}, func(h *models.Herb) string { return h.UpdatedBy }},
+}
+
+// defaultHerbColumns is used when --columns is not given
+const defaultHerbColumns = "id,name,latin,toxicity,created,updated,updated_by"
+
+// addTableFlags registers the flags that control table output
+func addTableFlags(cmd *cobra.Command) {
+	cmd.Flags().String("columns", defaultHerbColumns, "столбцы таблицы через запятую: id, name, latin, genus, species, desc, poisonous, toxicity, flowering, harvest, image, created, updated, created_by, updated_by")
+	cmd.Flags().String("style", "plain", "стиль таблицы: plain, markdown или box")
+}
+
+// wantsTable reports whether any of the table flags was set explicitly
+func wantsTable(cmd *cobra.Command) bool {
+	return cmd.Flags().Changed("columns") || cmd.Flags().Changed("style")
+}
+
+func herbColumnKeys() string {
+	keys := make([]string, len(herbColumns))
+	for i, column := range herbColumns {
+		keys[i] = column.Key
+	}
+	return strings.Join(keys, ", ")
+}
+
+// selectHerbColumns resolves a comma-separated list of column keys
+func selectHerbColumns(spec string) ([]herbColumn, error) {
+	var selected []herbColumn
+	for _, key := range strings.Split(spec, ",") {
+		key = strings.ToLower(strings.TrimSpace(key))
+		if key == "" {
+			continue
+		}
+		found := false
+		for _, column := range herbColumns {
+			if column.Key == key {
+				selected = append(selected, column)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, i18n.Errorf("неизвестный столбец: %s (доступно: %s)", key, herbColumnKeys())
+		}
+	}
+	if len(selected) == 0 {
+		return nil, i18n.Errorf("не выбрано ни одного столбца")
+	}
+	return selected, nil
+}
+
+// printHerbTable prints herbs as a table using the --columns and --style flags
+// of cmd, if it has them, and fits the table into the terminal width
+func printHerbTable(cmd *cobra.Command, herbs []models.Herb) error {
+	spec, style := defaultHerbColumns, table.StylePlain
+	if flag := cmd.Flags().Lookup("columns"); flag != nil {
+		spec = flag.Value.String()
+	}
+	if flag := cmd.Flags().Lookup("style"); flag != nil {
+		var err error
+		if style, err = table.ParseStyle(flag.Value.String()); err != nil {
+			return err
+		}
+	}
+
+	columns, err := selectHerbColumns(spec)
+	if err != nil {
+		return err
+	}
+
+	t := table.Table{Style: style, MaxWidth: terminalWidth()}
+	for _, column := range columns {
+		c := column.Column
+		c.Title = i18n.T(c.Title)
+		t.Columns = append(t.Columns, c)
+	}
+	for i := range herbs {
+		row := make([]string, len(columns))
+		for j, column := range columns {
+			row[j] = column.Value(&herbs[i])
+		}
+		t.AddRow(row...)
+	}
+	return t.Render(os.Stdout)
+}
+
+// terminalWidth returns the width of the terminal on stdout, or 0 when
+// the output is not a terminal and should not be limited
+func terminalWidth() int {
+	fd := int(os.Stdout.Fd())
+	if !term.IsTerminal(fd) {
+		return 0
+	}
+	width, _, err := term.GetSize(fd)
+	if err != nil {
+		return 0
+	}
+	return width
+}
+
+// formatDate formats t as a date in the display timezone
+func formatDate(t time.Time) string {
+	return t.In(models.DisplayLocation()).Format("2006-01-02")
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+	"github.com/gloowl/simple_crud/src/internal/models"
+)
+
+// fieldChange describes a single changed herb field
+type fieldChange struct {
+	Field string
+	Old   string
+	New   string
+}
+
+func (c fieldChange) String() string {
+	return fmt.Sprintf(i18n.T("%s: %q → %q"), c.Field, c.Old, c.New)
+}
+
+// diffHerbs lists the user-editable fields that differ between old and updated
+func diffHerbs(old, updated *models.Herb) []fieldChange {
+	var changes []fieldChange
+
+	add := func(field, oldValue, newValue string) {
+		if oldValue != newValue {
+			changes = append(changes, fieldChange{Field: field, Old: oldValue, New: newValue})
+		}
+	}
+
+	add("name", old.Name, updated.Name)
+	add("latin_name", old.LatinName, updated.LatinName)
+	add("description", old.Description, updated.Description)
+	add("toxicity", old.Toxicity.String(), updated.Toxicity.String())
+	add("toxic_parts", strings.Join(old.ToxicParts, ", "), strings.Join(updated.ToxicParts, ", "))
+	add("toxic_compounds", strings.Join(old.ToxicCompounds, ", "), strings.Join(updated.ToxicCompounds, ", "))
+	add("toxicity_symptoms", strings.Join(old.ToxicitySymptoms, ", "), strings.Join(updated.ToxicitySymptoms, ", "))
+	add("flowering_months", old.FloweringMonths.String(), updated.FloweringMonths.String())
+	add("harvest_months", old.HarvestMonths.String(), updated.HarvestMonths.String())
+	add("image_path", old.ImagePath, updated.ImagePath)
+
+	return changes
+}
@@ -0,0 +1,282 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/gloowl/simple_crud/src/internal/database"
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+	"github.com/gloowl/simple_crud/src/internal/models"
+	"github.com/gloowl/simple_crud/src/internal/repository"
+
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+// editHerbCmd edits a herb in the user's editor
+var editHerbCmd = &cobra.Command{
+	Use:   "edit [ID]",
+	Short: "Редактировать траву в текстовом редакторе",
+	Long: `Открывает траву с указанным ID в виде YAML в редакторе из $VISUAL или $EDITOR
+(по умолчанию vi). После сохранения запись проверяется; если в ней есть ошибки,
+редактор открывается снова с описанием ошибок в комментариях. Перед сохранением
+в базу данных показываются изменения.
+
+Чтобы отменить редактирование, сохраните пустой файл.`,
+	Args: cobra.ExactArgs(1),
+	Example: `  herbs-cli herb edit 1
+  EDITOR=nano herbs-cli herb edit 1`,
+	RunE: editHerb,
+}
+
+// herbDocument is the editable part of a herb as shown in the editor
+type herbDocument struct {
+	Name        string `yaml:"name"`
+	LatinName   string `yaml:"latin_name"`
+	Description string `yaml:"description"`
+	ImagePath   string `yaml:"image_path"`
+
+	Toxicity         models.ToxicityLevel `yaml:"toxicity"`
+	ToxicParts       []string             `yaml:"toxic_parts,flow"`
+	ToxicCompounds   []string             `yaml:"toxic_compounds,flow"`
+	ToxicitySymptoms []string             `yaml:"toxicity_symptoms,flow"`
+
+	Flowering string `yaml:"flowering"`
+	Harvest   string `yaml:"harvest"`
+}
+
+// editErrorPrefix marks the comment lines with errors from the previous attempt
+const editErrorPrefix = "# ОШИБКА: "
+
+func init() {
+	herbCmd.AddCommand(editHerbCmd)
+
+	editHerbCmd.Flags().BoolP("yes", "y", false, "сохранить без подтверждения")
+}
+
+func editHerb(cmd *cobra.Command, args []string) error {
+	db := database.GetDB()
+	if db == nil {
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+	}
+	herbRepo := repository.NewHerbRepository(db)
+
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return i18n.Errorf("неверный ID: %s", args[0])
+	}
+
+	if !isInteractive() {
+		return i18n.Errorf("для редактирования нужен терминал")
+	}
+
+	herb, err := herbRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	yes, _ := cmd.Flags().GetBool("yes")
+	return editAndSaveHerb(herbRepo, herb, yes)
+}
+
+// editAndSaveHerb opens herb in the editor, shows the changes and saves them
+// after confirmation (skipped when yes is set)
+func editAndSaveHerb(herbRepo *repository.HerbRepository, herb *models.Herb, yes bool) error {
+	edited, err := editHerbInEditor(herb)
+	if err != nil {
+		return err
+	}
+	if edited == nil {
+		fmt.Println(i18n.T("Редактирование отменено."))
+		return nil
+	}
+
+	changes := diffHerbs(herb, edited)
+	if len(changes) == 0 {
+		fmt.Println(i18n.T("Изменений нет."))
+		return nil
+	}
+
+	fmt.Printf(i18n.T("Изменения в траве ID %d:\n"), herb.ID)
+	for _, change := range changes {
+		fmt.Printf("  %s\n", change)
+	}
+
+	if !yes {
+		fmt.Println()
+		ok, err := confirm(i18n.T("Сохранить изменения?"))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println(i18n.T("Редактирование отменено."))
+			return nil
+		}
+	}
+
+	edited.UpdatedBy = identity
+	if err := herbRepo.Update(edited); err != nil {
+		return i18n.Errorf("не удалось обновить траву: %w", err)
+	}
+
+	fmt.Printf(i18n.T("✅ Трава с ID %d успешно обновлена\n"), edited.ID)
+	fmt.Println(edited.String())
+	return nil
+}
+
+// editHerbInEditor lets the user edit herb as YAML until it is valid.
+// It returns nil if the user saved an empty file.
+func editHerbInEditor(herb *models.Herb) (*models.Herb, error) {
+	file, err := os.CreateTemp("", fmt.Sprintf("herbs-cli-herb-%d-*.yaml", herb.ID))
+	if err != nil {
+		return nil, i18n.Errorf("не удалось создать временный файл: %v", err)
+	}
+	path := file.Name()
+	file.Close()
+	defer os.Remove(path)
+
+	content, err := renderHerbDocument(herb)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		if err := os.WriteFile(path, content, 0o600); err != nil {
+			return nil, i18n.Errorf("не удалось записать временный файл: %v", err)
+		}
+		if err := runEditor(path); err != nil {
+			return nil, err
+		}
+
+		content, err = os.ReadFile(path)
+		if err != nil {
+			return nil, i18n.Errorf("не удалось прочитать временный файл: %v", err)
+		}
+		content = stripEditErrors(content)
+		if len(bytes.TrimSpace(stripComments(content))) == 0 {
+			return nil, nil
+		}
+
+		edited, err := parseHerbDocument(herb, content)
+		if err == nil {
+			return edited, nil
+		}
+
+		// Show the problem at the top of the file and let the user fix it
+		var header bytes.Buffer
+		for _, line := range strings.Split(strings.TrimLeft(err.Error(), "\n"), "\n") {
+			header.WriteString(i18n.T(editErrorPrefix) + line + "\n")
+		}
+		content = append(header.Bytes(), content...)
+	}
+}
+
+// renderHerbDocument renders the editable fields of herb as commented YAML
+func renderHerbDocument(herb *models.Herb) ([]byte, error) {
+	data, err := yaml.Marshal(herbDocument{
+		Name:        herb.Name,
+		LatinName:   herb.LatinName,
+		Description: herb.Description,
+		ImagePath:   herb.ImagePath,
+
+		Toxicity:         herb.Toxicity,
+		ToxicParts:       herb.ToxicParts,
+		ToxicCompounds:   herb.ToxicCompounds,
+		ToxicitySymptoms: herb.ToxicitySymptoms,
+
+		Flowering: herb.FloweringMonths.String(),
+		Harvest:   herb.HarvestMonths.String(),
+	})
+	if err != nil {
+		return nil, i18n.Errorf("ошибка формирования YAML: %v", err)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, i18n.T("# Редактирование травы ID %d.\n"), herb.ID)
+	buf.WriteString(i18n.T("# Строки, начинающиеся с #, игнорируются. Сохраните пустой файл для отмены.\n"))
+	buf.Write(data)
+	return buf.Bytes(), nil
+}
+
+// parseHerbDocument applies the edited YAML to a copy of herb and validates the result
+func parseHerbDocument(herb *models.Herb, content []byte) (*models.Herb, error) {
+	var doc herbDocument
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, i18n.Errorf("ошибка разбора YAML: %v", err)
+	}
+
+	edited := *herb
+	edited.Name = strings.TrimSpace(doc.Name)
+	edited.LatinName = strings.TrimSpace(doc.LatinName)
+	edited.Description = strings.TrimSpace(doc.Description)
+	edited.SetToxicity(doc.Toxicity)
+	edited.ToxicParts = cleanList(doc.ToxicParts, true)
+	edited.ToxicCompounds = cleanList(doc.ToxicCompounds, false)
+	edited.ToxicitySymptoms = cleanList(doc.ToxicitySymptoms, false)
+	edited.ImagePath = strings.TrimSpace(doc.ImagePath)
+	warnLatinName(&edited)
+
+	var err error
+	if edited.FloweringMonths, err = models.ParseMonths(doc.Flowering); err != nil {
+		return nil, err
+	}
+	if edited.HarvestMonths, err = models.ParseMonths(doc.Harvest); err != nil {
+		return nil, err
+	}
+
+	if err := edited.Validate(); err != nil {
+		return nil, err
+	}
+	return &edited, nil
+}
+
+// runEditor opens path in the editor from $VISUAL or $EDITOR and waits for it to exit
+func runEditor(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	// The variable may contain arguments, e.g. "code --wait"
+	parts := strings.Fields(editor)
+	editorCmd := exec.Command(parts[0], append(parts[1:], path)...)
+	editorCmd.Stdin = os.Stdin
+	editorCmd.Stdout = os.Stdout
+	editorCmd.Stderr = os.Stderr
+
+	if err := editorCmd.Run(); err != nil {
+		return i18n.Errorf("ошибка запуска редактора %s: %v", editor, err)
+	}
+	return nil
+}
+
+// stripEditErrors removes error comments added by a previous attempt
+func stripEditErrors(content []byte) []byte {
+	lines := strings.SplitAfter(string(content), "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if !strings.HasPrefix(line, i18n.T(editErrorPrefix)) {
+			kept = append(kept, line)
+		}
+	}
+	return []byte(strings.Join(kept, ""))
+}
+
+// stripComments removes full-line comments, leaving only YAML content
+func stripComments(content []byte) []byte {
+	lines := strings.SplitAfter(string(content), "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if !strings.HasPrefix(strings.TrimSpace(line), "#") {
+			kept = append(kept, line)
+		}
+	}
+	return []byte(strings.Join(kept, ""))
+}
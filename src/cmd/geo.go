@@ -0,0 +1,274 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/gloowl/simple_crud/src/internal/database"
+	"github.com/gloowl/simple_crud/src/internal/geo"
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+	"github.com/gloowl/simple_crud/src/internal/models"
+	"github.com/gloowl/simple_crud/src/internal/repository"
+
+	"github.com/spf13/cobra"
+)
+
+// regionBoundaryCmd sets the boundary of a region
+var regionBoundaryCmd = &cobra.Command{
+	Use:   "boundary REGION [FILE]",
+	Short: "Задать границу региона в GeoJSON",
+	Long: `Сохраняет границу региона (ID или название) из GeoJSON-файла (- для чтения
+из stdin). Принимаются Polygon и MultiPolygon, а также Feature и FeatureCollection
+с такими геометриями; многоугольники всех объектов объединяются.
+Координаты указываются в порядке GeoJSON: долгота, затем широта.
+Границу, пересекающую антимеридиан (долготу 180), нужно разделить на
+многоугольники по обе стороны от него.
+
+С --clear граница удаляется.`,
+	Args: cobra.RangeArgs(1, 2),
+	Example: `  herbs-cli region boundary "Алтайский край" altai.geojson
+  cat altai.geojson | herbs-cli region boundary 3 -
+  herbs-cli region boundary 3 --clear`,
+	RunE: setRegionBoundary,
+}
+
+// exportRegionsCmd exports regions with boundaries as GeoJSON
+var exportRegionsCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Экспортировать регионы с травами в GeoJSON",
+	Long: `Выводит регионы, у которых задана граница, в виде GeoJSON FeatureCollection
+для картографических программ. В свойствах каждого объекта указываются название,
+описание и родительский регион, а также травы, привязанные к региону.`,
+	Example: `  herbs-cli region export > regions.geojson`,
+	RunE:    exportRegions,
+}
+
+// herbsAtCmd finds the herbs that grow at a point
+var herbsAtCmd = &cobra.Command{
+	Use:   "at",
+	Short: "Найти травы, растущие в точке",
+	Long: `Находит регионы, граница которых содержит точку с координатами --lat и --lon,
+и выводит травы, привязанные к этим регионам. Регионы перечисляются
+от меньшего к большему. Регионы без границы не учитываются.`,
+	Example: `  herbs-cli herb at --lat 51.96 --lon 85.96
+  herbs-cli herb at --lat 55.75 --lon 37.62 --output json`,
+	RunE: herbsAt,
+}
+
+func init() {
+	regionCmd.AddCommand(regionBoundaryCmd)
+	regionCmd.AddCommand(exportRegionsCmd)
+	herbCmd.AddCommand(herbsAtCmd)
+
+	regionBoundaryCmd.Flags().Bool("clear", false, "удалить границу региона")
+
+	herbsAtCmd.Flags().Float64("lat", 0, "широта в градусах")
+	herbsAtCmd.Flags().Float64("lon", 0, "долгота в градусах")
+	herbsAtCmd.MarkFlagRequired("lat")
+	herbsAtCmd.MarkFlagRequired("lon")
+	addOutputFlag(herbsAtCmd)
+}
+
+// regionArea is a region with its parsed boundary
+type regionArea struct {
+	region   models.Region
+	boundary *geo.Geometry
+}
+
+// loadRegionAreas parses the boundaries of all regions that have one
+func loadRegionAreas(repo *repository.RegionRepository) ([]regionArea, error) {
+	regions, err := repo.GetWithBoundaries()
+	if err != nil {
+		return nil, err
+	}
+
+	areas := make([]regionArea, 0, len(regions))
+	for _, region := range regions {
+		boundary, err := geo.Parse(region.Boundary)
+		if err != nil {
+			return nil, i18n.Errorf("граница региона «%s»: %w", region.Name, err)
+		}
+		areas = append(areas, regionArea{region: region, boundary: boundary})
+	}
+	return areas, nil
+}
+
+func setRegionBoundary(cmd *cobra.Command, args []string) error {
+	db := database.GetDB()
+	if db == nil {
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+	}
+	repo := repository.NewRegionRepository(db)
+
+	remove, _ := cmd.Flags().GetBool("clear")
+	if remove == (len(args) == 2) {
+		return i18n.Errorf("укажите файл с границей или флаг --clear")
+	}
+
+	region, err := resolveRegion(repo, args[0])
+	if err != nil {
+		return err
+	}
+
+	if remove {
+		if err := repo.SetBoundary(region.ID, nil); err != nil {
+			return err
+		}
+		fmt.Printf(i18n.T("✅ Граница региона «%s» удалена\n"), region.Name)
+		return nil
+	}
+
+	var data []byte
+	if args[1] == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(args[1])
+	}
+	if err != nil {
+		return i18n.Errorf("не удалось прочитать файл: %v", err)
+	}
+
+	boundary, err := geo.Parse(data)
+	if err != nil {
+		return err
+	}
+	// The boundary is stored as a plain geometry, without the Feature wrappers
+	normalized, err := json.Marshal(boundary)
+	if err != nil {
+		return i18n.Errorf("ошибка формирования GeoJSON: %v", err)
+	}
+
+	if err := repo.SetBoundary(region.ID, normalized); err != nil {
+		return err
+	}
+	fmt.Printf(i18n.T("✅ Граница региона «%s» сохранена (многоугольников: %d)\n"), region.Name, len(boundary.Polygons))
+	return nil
+}
+
+func exportRegions(cmd *cobra.Command, args []string) error {
+	db := database.GetDB()
+	if db == nil {
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+	}
+	repo := repository.NewRegionRepository(db)
+
+	areas, err := loadRegionAreas(repo)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]int, len(areas))
+	for i, area := range areas {
+		ids[i] = area.region.ID
+	}
+	herbsByRegion, err := repo.GetHerbs(ids)
+	if err != nil {
+		return err
+	}
+
+	features := make([]geo.Feature, len(areas))
+	for i, area := range areas {
+		herbs := []map[string]any{}
+		for _, herb := range herbsByRegion[area.region.ID] {
+			herbs = append(herbs, map[string]any{
+				"id":         herb.ID,
+				"name":       herb.Name,
+				"latin_name": herb.LatinName,
+				"toxicity":   herb.Toxicity.String(),
+			})
+		}
+		features[i] = geo.Feature{
+			ID:       area.region.ID,
+			Geometry: area.boundary,
+			Properties: map[string]any{
+				"name":        area.region.Name,
+				"description": area.region.Description,
+				"parent_id":   area.region.ParentID,
+				"herbs":       herbs,
+			},
+		}
+	}
+
+	return geo.WriteFeatureCollection(os.Stdout, features)
+}
+
+func herbsAt(cmd *cobra.Command, args []string) error {
+	db := database.GetDB()
+	if db == nil {
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+	}
+	repo := repository.NewRegionRepository(db)
+
+	lat, _ := cmd.Flags().GetFloat64("lat")
+	lon, _ := cmd.Flags().GetFloat64("lon")
+	point := geo.Point{lon, lat}
+	if !geo.ValidPoint(point) {
+		return i18n.Errorf("неверные координаты: широта должна быть от -90 до 90, долгота - от -180 до 180")
+	}
+
+	areas, err := loadRegionAreas(repo)
+	if err != nil {
+		return err
+	}
+
+	var found []regionArea
+	for _, area := range areas {
+		if area.boundary.Contains(point) {
+			found = append(found, area)
+		}
+	}
+	// The most specific region, i.e. the smallest one, comes first
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].boundary.Area() < found[j].boundary.Area()
+	})
+
+	ids := make([]int, len(found))
+	names := make([]string, len(found))
+	for i, area := range found {
+		ids[i], names[i] = area.region.ID, area.region.Name
+	}
+	herbsByRegion, err := repo.GetHerbs(ids)
+	if err != nil {
+		return err
+	}
+
+	// A herb linked to several of the regions is listed once, under the smallest one
+	var herbs []models.Herb
+	seen := make(map[int]bool)
+	for _, id := range ids {
+		for _, herb := range herbsByRegion[id] {
+			if !seen[herb.ID] {
+				seen[herb.ID] = true
+				herbs = append(herbs, herb)
+			}
+		}
+	}
+
+	if handled, err := printMachineOutput(cmd, herbs, false); handled {
+		return err
+	}
+
+	if len(found) == 0 {
+		fmt.Printf(i18n.T("Точка %g, %g не входит ни в один регион с заданной границей\n"), lat, lon)
+		return nil
+	}
+	fmt.Printf(i18n.T("Регионы: %s\n"), strings.Join(names, ", "))
+
+	if len(herbs) == 0 {
+		fmt.Println(i18n.T("Травы в этих регионах не найдены"))
+		return nil
+	}
+
+	fmt.Printf(i18n.T("Найдено трав: %d\n\n"), len(herbs))
+	for i, herb := range herbs {
+		if i > 0 {
+			fmt.Println("\n" + strings.Repeat("-", 50))
+		}
+		fmt.Println(herb.String())
+	}
+	return nil
+}
@@ -3,10 +3,13 @@ package cmd
 import (
 	"fmt"
 	"github.com/gloowl/simple_crud/src/internal/database"
+	"github.com/gloowl/simple_crud/src/internal/i18n"
 	"github.com/gloowl/simple_crud/src/internal/models"
 	"github.com/gloowl/simple_crud/src/internal/repository"
+	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -33,9 +36,12 @@ var herbCmd = &cobra.Command{
 var createHerbCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Создать новую траву",
-	Long:  `Создает новую запись о лекарственной траве в базе данных.`,
-	Example: `  herbs-cli herb create --name "Ромашка" --latin "Matricaria chamomilla" --desc "Противовоспалительное средство"
-  herbs-cli herb create --name "Белена" --latin "Hyoscyamus niger" --desc "Ядовитое растение" --poisonous`,
+	Long: `Создает новую запись о лекарственной траве в базе данных.
+Если команда запущена в терминале без флагов, значения полей запрашиваются
+по очереди, с возможностью выбрать регионы и типы использования.`,
+	Example: `  herbs-cli herb create
+  herbs-cli herb create --name "Ромашка" --latin "Matricaria chamomilla" --desc "Противовоспалительное средство"
+  herbs-cli herb create --name "Белена" --latin "Hyoscyamus niger" --desc "Ядовитое растение" --toxicity severe --toxic-parts whole --compounds атропин,скополамин`,
 	RunE: createHerb,
 }
 
@@ -44,17 +50,47 @@ var listHerbsCmd = &cobra.Command{
 	Use:     "list",
 	Aliases: []string{"ls"},
 	Short:   "Показать все травы",
-	Long:    `Выводит список всех лекарственных трав из базы данных.`,
-	RunE:    listHerbs,
+	Long: `Выводит список всех лекарственных трав из базы данных.
+
+В табличном формате (--table) можно выбрать столбцы с помощью --columns
+и стиль оформления с помощью --style: plain (без рамок), markdown или box.
+Таблица сужается по ширине терминала.
+
+Флаг --genus оставляет только травы указанного рода (без учета регистра),
+флаг --family - травы всех родов и видов семейства (см. taxonomy tree),
+флаг --region - травы, растущие в регионе или в любом из его подрегионов
+(см. region tree).
+
+Флаг --format задает шаблон Go text/template, который выполняется для каждой
+травы (так же работает в get, search и poisonous). В шаблоне доступны поля
+травы (.ID, .Name, .LatinName, .Genus, .Species, .InfraRank, .InfraEpithet,
+.Authorship, .Description, .IsPoisonous, .Toxicity, .ToxicParts, .ToxicCompounds,
+.ToxicitySymptoms, .ImagePath, .CreatedAt, .UpdatedAt, .CreatedBy, .UpdatedBy),
+сама трава .Herb, а также ее регионы .Regions, способы применения .Usages
+и другие названия .Names, которые загружаются, только если шаблон к ним обращается.
+Функции: truncate N, upper, lower, date "2006-01-02", join SEP, pluck "Поле".
+Вместо шаблона можно указать имя файла NAME.tmpl из каталога шаблонов
+(templates_dir в конфигурации, по умолчанию ~/.config/herbs-cli/templates).`,
+	Example: `  herbs-cli herb list --table
+  herbs-cli herb list --columns id,name,latin,desc
+  herbs-cli herb list --genus Mentha --columns id,name,genus,species
+  herbs-cli herb list --family Solanaceae
+  herbs-cli herb list --columns id,name,poisonous --style markdown
+  herbs-cli herb list --format '{{.Name}} ({{.LatinName}})'
+  herbs-cli herb list --format '{{.Herb.Name | upper}}: {{join ", " (pluck "Name" .Regions)}}'
+  herbs-cli herb list --format label`,
+	RunE: listHerbs,
 }
 
 // getHerbCmd gets a herb by ID
 var getHerbCmd = &cobra.Command{
 	Use:   "get [ID]",
 	Short: "Получить траву по ID",
-	Long:  `Выводит подробную информацию о траве с указанным ID.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  getHerb,
+	Long: `Выводит подробную информацию о траве с указанным ID. Если неядовитую траву
+легко спутать с ядовитой (см. herb lookalikes), выводится предупреждение.
+С --sources выводятся источники сведений о траве (см. herb cite).`,
+	Args: cobra.ExactArgs(1),
+	RunE: getHerb,
 }
 
 // updateHerbCmd updates a herb
@@ -68,21 +104,31 @@ var updateHerbCmd = &cobra.Command{
 	RunE: updateHerb,
 }
 
-// deleteHerbCmd deletes a herb
+// deleteHerbCmd deletes herbs by ID or by filter
 var deleteHerbCmd = &cobra.Command{
-	Use:   "delete [ID]",
-	Short: "Удалить траву",
-	Long:  `Удаляет траву с указанным ID из базы данных.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  deleteHerb,
+	Use:   "delete [ID...]",
+	Short: "Удалить травы",
+	Long: `Удаляет травы с указанными ID или подходящие под фильтр из базы данных.
+ID можно перечислять через пробел или запятую и задавать диапазонами (5-9).
+Перед удалением показывается список удаляемых трав и запрашивается подтверждение;
+все травы удаляются в одной транзакции.
+
+Условия --where:
+  poisonous      - только ядовитые травы
+  not-poisonous  - только неядовитые травы`,
+	Example: `  herbs-cli herb delete 1
+  herbs-cli herb delete 1 3 5-9 --yes
+  herbs-cli herb delete --where poisonous --created-before 2025-01-01`,
+	RunE: deleteHerb,
 }
 
 // searchHerbCmd searches herbs by name
 var searchHerbCmd = &cobra.Command{
 	Use:   "search [название]",
 	Short: "Найти травы по названию",
-	Long:  `Выполняет поиск трав по названию (поддерживает частичное совпадение).`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Выполняет поиск трав по названию, латинскому названию и другим названиям
+(народным и синонимам) с поддержкой частичного совпадения.`,
+	Args: cobra.ExactArgs(1),
 	Example: `  herbs-cli herb search "ромашка"
   herbs-cli herb search "Matricaria"`,
 	RunE: searchHerbs,
@@ -92,8 +138,16 @@ var searchHerbCmd = &cobra.Command{
 var poisonousHerbsCmd = &cobra.Command{
 	Use:   "poisonous",
 	Short: "Показать ядовитые травы",
-	Long:  `Выводит список всех ядовитых трав из базы данных.`,
-	RunE:  listPoisonousHerbs,
+	Long: `Выводит список ядовитых трав из базы данных, начиная с самых опасных.
+
+По умолчанию показываются травы со степенью токсичности moderate и выше;
+--min-level задает другую минимальную степень: mild, moderate, severe или deadly.
+В таблице степень отмечается знаками: ! слабая, !! умеренная, !!! сильная,
+☠️ !!!! смертельная.`,
+	Example: `  herbs-cli herb poisonous
+  herbs-cli herb poisonous --min-level deadly
+  herbs-cli herb poisonous --min-level mild --table`,
+	RunE: listPoisonousHerbs,
 }
 
 func init() {
@@ -109,51 +163,92 @@ func init() {
 	herbCmd.AddCommand(poisonousHerbsCmd)
 
 	// Flags for create command
-	createHerbCmd.Flags().StringP("name", "n", "", "название травы (обязательно)")
+	createHerbCmd.Flags().StringP("name", "n", "", "название травы (обязательно без интерактивного режима)")
 	createHerbCmd.Flags().StringP("latin", "l", "", "латинское название")
 	createHerbCmd.Flags().StringP("desc", "d", "", "описание травы")
-	createHerbCmd.Flags().BoolP("poisonous", "p", false, "является ли трава ядовитой")
+	createHerbCmd.Flags().BoolP("poisonous", "p", false, "является ли трава ядовитой (то же, что --toxicity severe)")
 	createHerbCmd.Flags().StringP("image", "i", "", "путь к изображению")
-	createHerbCmd.MarkFlagRequired("name")
+	addToxicityFlags(createHerbCmd)
+	addSeasonFlags(createHerbCmd)
 
 	// Flags for update command
 	updateHerbCmd.Flags().StringP("name", "n", "", "новое название травы")
 	updateHerbCmd.Flags().StringP("latin", "l", "", "новое латинское название")
 	updateHerbCmd.Flags().StringP("desc", "d", "", "новое описание травы")
-	updateHerbCmd.Flags().BoolP("poisonous", "p", false, "является ли трава ядовитой")
+	updateHerbCmd.Flags().BoolP("poisonous", "p", false, "является ли трава ядовитой (то же, что --toxicity severe)")
 	updateHerbCmd.Flags().StringP("image", "i", "", "новый путь к изображению")
+	addToxicityFlags(updateHerbCmd)
+	addSeasonFlags(updateHerbCmd)
+
+	// Flags for delete command
+	deleteHerbCmd.Flags().BoolP("yes", "y", false, "удалить без подтверждения")
+	deleteHerbCmd.Flags().BoolP("force", "f", false, "то же, что --yes")
+	deleteHerbCmd.Flags().StringSlice("where", nil, "условия отбора: poisonous, not-poisonous")
+	deleteHerbCmd.Flags().String("created-before", "", "удалить травы, созданные раньше даты (ГГГГ-ММ-ДД)")
+	deleteHerbCmd.Flags().String("created-after", "", "удалить травы, созданные не раньше даты (ГГГГ-ММ-ДД)")
+
+	// Flags for get command
+	getHerbCmd.Flags().Bool("sources", false, "показать источники сведений о траве")
 
 	// Flags for list command
 	listHerbsCmd.Flags().BoolP("table", "t", false, "вывод в табличном формате")
+	listHerbsCmd.Flags().String("genus", "", "показать только травы указанного рода")
+	listHerbsCmd.Flags().String("family", "", "показать только травы указанного семейства")
+	listHerbsCmd.Flags().String("region", "", "показать только травы региона и его подрегионов (ID или название)")
+	addTableFlags(listHerbsCmd)
+
+	// Flags for poisonous command
+	poisonousHerbsCmd.Flags().String("min-level", models.PoisonousLevel.String(), "минимальная степень токсичности: mild, moderate, severe или deadly")
+	poisonousHerbsCmd.Flags().BoolP("table", "t", false, "вывод в табличном формате")
+	addTableFlags(poisonousHerbsCmd)
+
+	// Output format flags
+	addOutputFlag(listHerbsCmd)
+	addOutputFlag(getHerbCmd)
+	addOutputFlag(searchHerbCmd)
+	addOutputFlag(poisonousHerbsCmd)
 }
 
 func createHerb(cmd *cobra.Command, args []string) error {
 	db := database.GetDB()
 	if db == nil {
-		return fmt.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
 	}
 	herbRepo := repository.NewHerbRepository(db)
 
+	if !hasHerbCreateFlags(cmd.Flags().Changed) {
+		if isInteractive() {
+			return runCreateWizard(repository.NewRepositories(db), repository.NewUnitOfWork(db))
+		}
+		return i18n.Errorf("флаг --name обязателен, если ввод не является терминалом")
+	}
+
 	name, _ := cmd.Flags().GetString("name")
 	latinName, _ := cmd.Flags().GetString("latin")
 	description, _ := cmd.Flags().GetString("desc")
-	isPoisonous, _ := cmd.Flags().GetBool("poisonous")
 	imagePath, _ := cmd.Flags().GetString("image")
 
 	herb := &models.Herb{
 		Name:        strings.TrimSpace(name),
 		LatinName:   strings.TrimSpace(latinName),
 		Description: strings.TrimSpace(description),
-		IsPoisonous: isPoisonous,
 		ImagePath:   strings.TrimSpace(imagePath),
+		CreatedBy:   identity,
+	}
+	if err := applyToxicityFlags(cmd, herb); err != nil {
+		return err
 	}
+	if err := applySeasonFlags(cmd, herb); err != nil {
+		return err
+	}
+	warnLatinName(herb)
 
 	err := herbRepo.Create(herb)
 	if err != nil {
-		return fmt.Errorf("не удалось создать траву: %v", err)
+		return i18n.Errorf("не удалось создать траву: %w", err)
 	}
 
-	fmt.Printf("✅ Трава успешно создана с ID: %d\n", herb.ID)
+	fmt.Printf(i18n.T("✅ Трава успешно создана с ID: %d\n"), herb.ID)
 	fmt.Println(herb.String())
 	return nil
 }
@@ -161,39 +256,52 @@ func createHerb(cmd *cobra.Command, args []string) error {
 func listHerbs(cmd *cobra.Command, args []string) error {
 	db := database.GetDB()
 	if db == nil {
-		return fmt.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
 	}
 	herbRepo := repository.NewHerbRepository(db)
 
-	herbs, err := herbRepo.GetAll()
+	genus, _ := cmd.Flags().GetString("genus")
+	family, _ := cmd.Flags().GetString("family")
+	filter := repository.HerbFilter{
+		Genus:  strings.TrimSpace(genus),
+		Family: strings.TrimSpace(family),
+	}
+	if regionArg, _ := cmd.Flags().GetString("region"); strings.TrimSpace(regionArg) != "" {
+		region, err := resolveRegion(repository.NewRegionRepository(db), regionArg)
+		if err != nil {
+			return err
+		}
+		filter.RegionID = region.ID
+	}
+
+	herbs, err := herbRepo.Find(filter)
 	if err != nil {
-		return fmt.Errorf("не удалось получить список трав: %v", err)
+		return i18n.Errorf("не удалось получить список трав: %v", err)
+	}
+
+	if handled, err := printMachineOutput(cmd, herbs, false); handled {
+		return err
 	}
 
 	if len(herbs) == 0 {
-		fmt.Println("База данных пуста. Добавьте травы с помощью команды 'create'.")
+		fmt.Println(i18n.T("База данных пуста. Добавьте травы с помощью команды 'create'."))
 		return nil
 	}
 
 	tableFormat, _ := cmd.Flags().GetBool("table")
 
-	fmt.Printf("Найдено трав: %d\n\n", len(herbs))
+	fmt.Printf(i18n.T("Найдено трав: %d\n\n"), len(herbs))
 
-	if tableFormat {
-		// Table format
-		fmt.Println(herbs[0].TableHeader())
-		fmt.Println(strings.Repeat("-", 80))
-		for _, herb := range herbs {
-			fmt.Println(herb.TableRow())
-		}
-	} else {
-		// Detailed format
-		for i, herb := range herbs {
-			if i > 0 {
-				fmt.Println("\n" + strings.Repeat("-", 50))
-			}
-			fmt.Println(herb.String())
+	if tableFormat || wantsTable(cmd) {
+		return printHerbTable(cmd, herbs)
+	}
+
+	// Detailed format
+	for i, herb := range herbs {
+		if i > 0 {
+			fmt.Println("\n" + strings.Repeat("-", 50))
 		}
+		fmt.Println(herb.String())
 	}
 
 	return nil
@@ -202,13 +310,13 @@ func listHerbs(cmd *cobra.Command, args []string) error {
 func getHerb(cmd *cobra.Command, args []string) error {
 	db := database.GetDB()
 	if db == nil {
-		return fmt.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
 	}
 	herbRepo := repository.NewHerbRepository(db)
 
 	id, err := strconv.Atoi(args[0])
 	if err != nil {
-		return fmt.Errorf("неверный ID: %s", args[0])
+		return i18n.Errorf("неверный ID: %s", args[0])
 	}
 
 	herb, err := herbRepo.GetByID(id)
@@ -216,20 +324,55 @@ func getHerb(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if handled, err := printMachineOutput(cmd, []models.Herb{*herb}, true); handled {
+		return err
+	}
+
+	lookalikes, err := repository.NewLookAlikeRepository(db).GetByHerb(herb.ID)
+	if err != nil {
+		return err
+	}
+
+	printLookAlikeWarning(herb, lookalikes)
 	fmt.Println(herb.String())
+
+	names, err := repository.NewHerbNameRepository(db).GetByHerb(herb.ID)
+	if err != nil {
+		return err
+	}
+	printHerbNames(names)
+
+	contraindications, err := repository.NewContraindicationRepository(db).Find([]int{herb.ID}, nil)
+	if err != nil {
+		return err
+	}
+	interactions, err := repository.NewInteractionRepository(db).Find([]int{herb.ID}, nil)
+	if err != nil {
+		return err
+	}
+	printHerbSafety(contraindications, interactions)
+
+	if showSources, _ := cmd.Flags().GetBool("sources"); showSources {
+		citations, err := repository.NewCitationRepository(db).GetByHerb(herb.ID)
+		if err != nil {
+			return err
+		}
+		printHerbCitations(citations)
+	}
+
 	return nil
 }
 
 func updateHerb(cmd *cobra.Command, args []string) error {
 	db := database.GetDB()
 	if db == nil {
-		return fmt.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
 	}
 	herbRepo := repository.NewHerbRepository(db)
 
 	id, err := strconv.Atoi(args[0])
 	if err != nil {
-		return fmt.Errorf("неверный ID: %s", args[0])
+		return i18n.Errorf("неверный ID: %s", args[0])
 	}
 
 	// Get existing herb
@@ -246,26 +389,30 @@ func updateHerb(cmd *cobra.Command, args []string) error {
 	if cmd.Flags().Changed("latin") {
 		latin, _ := cmd.Flags().GetString("latin")
 		herb.LatinName = strings.TrimSpace(latin)
+		warnLatinName(herb)
 	}
 	if cmd.Flags().Changed("desc") {
 		desc, _ := cmd.Flags().GetString("desc")
 		herb.Description = strings.TrimSpace(desc)
 	}
-	if cmd.Flags().Changed("poisonous") {
-		poisonous, _ := cmd.Flags().GetBool("poisonous")
-		herb.IsPoisonous = poisonous
+	if err := applyToxicityFlags(cmd, herb); err != nil {
+		return err
+	}
+	if err := applySeasonFlags(cmd, herb); err != nil {
+		return err
 	}
 	if cmd.Flags().Changed("image") {
 		image, _ := cmd.Flags().GetString("image")
 		herb.ImagePath = strings.TrimSpace(image)
 	}
 
+	herb.UpdatedBy = identity
 	err = herbRepo.Update(herb)
 	if err != nil {
-		return fmt.Errorf("не удалось обновить траву: %v", err)
+		return i18n.Errorf("не удалось обновить траву: %w", err)
 	}
 
-	fmt.Printf("✅ Трава с ID %d успешно обновлена\n", herb.ID)
+	fmt.Printf(i18n.T("✅ Трава с ID %d успешно обновлена\n"), herb.ID)
 	fmt.Println(herb.String())
 	return nil
 }
@@ -273,61 +420,160 @@ func updateHerb(cmd *cobra.Command, args []string) error {
 func deleteHerb(cmd *cobra.Command, args []string) error {
 	db := database.GetDB()
 	if db == nil {
-		return fmt.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
 	}
 	herbRepo := repository.NewHerbRepository(db)
 
-	id, err := strconv.Atoi(args[0])
+	filter, err := deleteFilter(cmd, args)
 	if err != nil {
-		return fmt.Errorf("неверный ID: %s", args[0])
+		return err
 	}
 
-	// Show herb before deletion
-	herb, err := herbRepo.GetByID(id)
+	herbs, err := herbRepo.Find(filter)
 	if err != nil {
 		return err
 	}
 
-	fmt.Println("Удаляется следующая трава:")
-	fmt.Println(herb.String())
-	fmt.Print("\nВы уверены? (y/N): ")
-
-	var confirmation string
-	fmt.Scanln(&confirmation)
+	if missing := missingIDs(filter.IDs, herbs); len(missing) > 0 {
+		return i18n.Errorf("травы с ID %s не найдены", joinIDs(missing))
+	}
 
-	if confirmation != "y" && confirmation != "Y" {
-		fmt.Println("Удаление отменено.")
+	if len(herbs) == 0 {
+		fmt.Println(i18n.T("Нет трав, подходящих под условия."))
 		return nil
 	}
 
-	err = herbRepo.Delete(id)
+	fmt.Printf(i18n.T("Будет удалено трав: %d\n\n"), len(herbs))
+	if err := printHerbTable(cmd, herbs); err != nil {
+		return err
+	}
+
+	yes, _ := cmd.Flags().GetBool("yes")
+	force, _ := cmd.Flags().GetBool("force")
+	if !yes && !force {
+		fmt.Println()
+		ok, err := confirm(i18n.T("Вы уверены?"))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println(i18n.T("Удаление отменено."))
+			return nil
+		}
+	}
+
+	ids := make([]int, len(herbs))
+	for i, herb := range herbs {
+		ids[i] = herb.ID
+	}
+
+	err = repository.NewUnitOfWork(db).Do(func(repos *repository.Repositories) error {
+		return repos.Herbs.DeleteMany(ids)
+	})
 	if err != nil {
-		return fmt.Errorf("не удалось удалить траву: %v", err)
+		return i18n.Errorf("не удалось удалить травы: %v", err)
 	}
 
-	fmt.Printf("✅ Трава с ID %d успешно удалена\n", id)
+	fmt.Printf(i18n.T("✅ Удалено трав: %d (ID %s)\n"), len(ids), joinIDs(ids))
 	return nil
 }
 
+// deleteFilter builds the selection for herb delete from IDs or filter flags
+func deleteFilter(cmd *cobra.Command, args []string) (repository.HerbFilter, error) {
+	var filter repository.HerbFilter
+
+	conditions, _ := cmd.Flags().GetStringSlice("where")
+	for _, condition := range conditions {
+		switch strings.TrimSpace(condition) {
+		case "poisonous":
+			poisonous := true
+			filter.Poisonous = &poisonous
+		case "not-poisonous", "!poisonous":
+			poisonous := false
+			filter.Poisonous = &poisonous
+		default:
+			return filter, i18n.Errorf("неизвестное условие --where: %s (доступно: poisonous, not-poisonous)", condition)
+		}
+	}
+
+	for flag, target := range map[string]*time.Time{
+		"created-before": &filter.CreatedBefore,
+		"created-after":  &filter.CreatedAfter,
+	} {
+		if value, _ := cmd.Flags().GetString(flag); value != "" {
+			date, err := parseDate(value)
+			if err != nil {
+				return filter, err
+			}
+			*target = date
+		}
+	}
+
+	if len(args) > 0 {
+		if !filter.IsEmpty() {
+			return filter, i18n.Errorf("укажите либо ID, либо условия отбора, но не то и другое вместе")
+		}
+		ids, err := parseIDList(args)
+		if err != nil {
+			return filter, err
+		}
+		filter.IDs = ids
+	}
+
+	if filter.IsEmpty() {
+		return filter, i18n.Errorf("укажите ID трав или условия отбора (--where, --created-before, --created-after)")
+	}
+	return filter, nil
+}
+
+// missingIDs returns the requested IDs that are absent from herbs
+func missingIDs(ids []int, herbs []models.Herb) []int {
+	found := make(map[int]bool, len(herbs))
+	for _, herb := range herbs {
+		found[herb.ID] = true
+	}
+
+	var missing []int
+	for _, id := range ids {
+		if !found[id] {
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
+
+// joinIDs formats IDs as a comma-separated list
+func joinIDs(ids []int) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.Itoa(id)
+	}
+	return strings.Join(parts, ", ")
+}
+
 func searchHerbs(cmd *cobra.Command, args []string) error {
 	db := database.GetDB()
 	if db == nil {
-		return fmt.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
 	}
 	herbRepo := repository.NewHerbRepository(db)
 
 	searchTerm := args[0]
 	herbs, err := herbRepo.Search(searchTerm)
 	if err != nil {
-		return fmt.Errorf("ошибка поиска: %v", err)
+		return i18n.Errorf("ошибка поиска: %v", err)
+	}
+
+	if handled, err := printMachineOutput(cmd, herbs, false); handled {
+		return err
 	}
 
 	if len(herbs) == 0 {
-		fmt.Printf("Травы с названием '%s' не найдены.\n", searchTerm)
+		fmt.Printf(i18n.T("Травы с названием '%s' не найдены.\n"), searchTerm)
 		return nil
 	}
 
-	fmt.Printf("Найдено трав по запросу '%s': %d\n\n", searchTerm, len(herbs))
+	fmt.Printf(i18n.T("Найдено трав по запросу '%s': %d\n\n"), searchTerm, len(herbs))
 
 	for i, herb := range herbs {
 		if i > 0 {
@@ -342,21 +588,38 @@ func searchHerbs(cmd *cobra.Command, args []string) error {
 func listPoisonousHerbs(cmd *cobra.Command, args []string) error {
 	db := database.GetDB()
 	if db == nil {
-		return fmt.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
 	}
 	herbRepo := repository.NewHerbRepository(db)
 
-	herbs, err := herbRepo.GetPoisonous()
+	minLevelFlag, _ := cmd.Flags().GetString("min-level")
+	minLevel, err := models.ParseToxicityLevel(minLevelFlag)
 	if err != nil {
-		return fmt.Errorf("не удалось получить список ядовитых трав: %v", err)
+		return err
+	}
+	if minLevel == models.ToxicityNone {
+		minLevel = models.ToxicityMild
+	}
+
+	herbs, err := herbRepo.GetPoisonous(minLevel)
+	if err != nil {
+		return i18n.Errorf("не удалось получить список ядовитых трав: %v", err)
+	}
+
+	if handled, err := printMachineOutput(cmd, herbs, false); handled {
+		return err
 	}
 
 	if len(herbs) == 0 {
-		fmt.Println("В базе данных нет записей о ядовитых травах.")
+		fmt.Println(i18n.T("В базе данных нет записей о ядовитых травах."))
 		return nil
 	}
 
-	fmt.Printf("⚠️  Найдено ядовитых трав: %d\n\n", len(herbs))
+	fmt.Printf(i18n.T("⚠️  Найдено ядовитых трав: %d\n\n"), len(herbs))
+
+	if tableFormat, _ := cmd.Flags().GetBool("table"); tableFormat || wantsTable(cmd) {
+		return printHerbTable(cmd, herbs)
+	}
 
 	for i, herb := range herbs {
 		if i > 0 {
@@ -367,3 +630,10 @@ func listPoisonousHerbs(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// warnLatinName normalizes the latin name of herb and prints the problems found in it
+func warnLatinName(herb *models.Herb) {
+	for _, warning := range herb.NormalizeLatinName() {
+		fmt.Fprintf(os.Stderr, i18n.T("⚠️  латинское название «%s»: %s\n"), herb.LatinName, warning)
+	}
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// commandTexts keeps the original help texts of a command, so that they can
+// be translated again when the language changes
+type commandTexts struct {
+	short, long, example string
+}
+
+var (
+	originalCommandTexts = map[*cobra.Command]commandTexts{}
+	originalFlagUsages   = map[*pflag.Flag]string{}
+)
+
+// applyLanguage selects the message language and the plain mode from the flags
+// and translates the help of root and all its subcommands
+func applyLanguage(root *cobra.Command) error {
+	i18n.SetPlain(plain)
+	if err := i18n.SetLanguage(lang); err != nil {
+		return err
+	}
+	localizeCommand(root)
+	return nil
+}
+
+// localizeCommand translates the descriptions of cmd, its subcommands and their flags
+func localizeCommand(cmd *cobra.Command) {
+	texts, ok := originalCommandTexts[cmd]
+	if !ok {
+		texts = commandTexts{short: cmd.Short, long: cmd.Long, example: cmd.Example}
+		originalCommandTexts[cmd] = texts
+	}
+	cmd.Short = i18n.T(texts.short)
+	cmd.Long = i18n.T(texts.long)
+	cmd.Example = i18n.T(texts.example)
+
+	localizeFlag := func(flag *pflag.Flag) {
+		usage, ok := originalFlagUsages[flag]
+		if !ok {
+			usage = flag.Usage
+			originalFlagUsages[flag] = usage
+		}
+		flag.Usage = i18n.T(usage)
+	}
+	cmd.Flags().VisitAll(localizeFlag)
+	cmd.PersistentFlags().VisitAll(localizeFlag)
+
+	for _, sub := range cmd.Commands() {
+		localizeCommand(sub)
+	}
+}
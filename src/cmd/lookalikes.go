@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gloowl/simple_crud/src/internal/database"
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+	"github.com/gloowl/simple_crud/src/internal/models"
+	"github.com/gloowl/simple_crud/src/internal/repository"
+
+	"github.com/spf13/cobra"
+)
+
+// lookalikesHerbCmd lists the herbs that look like a herb
+var lookalikesHerbCmd = &cobra.Command{
+	Use:   "lookalikes ID",
+	Short: "Показать травы, с которыми легко спутать траву",
+	Long: `Выводит травы, внешне похожие на траву с указанным ID, начиная с самых
+ядовитых, и признаки, по которым их можно различить.`,
+	Args: cobra.ExactArgs(1),
+	Example: `  herbs-cli herb lookalikes 12
+  herbs-cli herb lookalikes add 12 31 --notes "у болиголова стебель с красными пятнами и неприятный запах"
+  herbs-cli herb lookalikes delete 12 31`,
+	RunE: listLookAlikes,
+}
+
+// addLookAlikeCmd marks two herbs as look-alikes
+var addLookAlikeCmd = &cobra.Command{
+	Use:   "add ID OTHER_ID",
+	Short: "Отметить травы как похожие",
+	Long: `Отмечает две травы как внешне похожие. Связь симметрична; повторный вызов
+заменяет описание отличий.`,
+	Args: cobra.ExactArgs(2),
+	RunE: addLookAlike,
+}
+
+// deleteLookAlikeCmd removes the look-alike relation
+var deleteLookAlikeCmd = &cobra.Command{
+	Use:   "delete ID OTHER_ID",
+	Short: "Убрать отметку о сходстве трав",
+	Args:  cobra.ExactArgs(2),
+	RunE:  deleteLookAlike,
+}
+
+func init() {
+	herbCmd.AddCommand(lookalikesHerbCmd)
+	lookalikesHerbCmd.AddCommand(addLookAlikeCmd)
+	lookalikesHerbCmd.AddCommand(deleteLookAlikeCmd)
+
+	addLookAlikeCmd.Flags().String("notes", "", "как отличить травы друг от друга")
+}
+
+// printLookAlikeWarning prints a prominent warning if herb is not poisonous
+// but can be confused with a poisonous herb
+func printLookAlikeWarning(herb *models.Herb, lookalikes []models.LookAlike) {
+	var dangerous []models.LookAlike
+	for _, lookalike := range lookalikes {
+		if lookalike.IsDangerous(herb) {
+			dangerous = append(dangerous, lookalike)
+		}
+	}
+	if len(dangerous) == 0 {
+		return
+	}
+
+	line := strings.Repeat("!", 60)
+	fmt.Println(line)
+	fmt.Println(i18n.T("⚠️  ОСТОРОЖНО: эту траву легко спутать с ядовитыми растениями!"))
+	for _, lookalike := range dangerous {
+		printLookAlike(lookalike)
+	}
+	fmt.Println(line)
+}
+
+// printLookAlike prints a look-alike herb with its toxicity and distinguishing features
+func printLookAlike(lookalike models.LookAlike) {
+	fmt.Printf(i18n.T("  • %s (ID %d) — токсичность: %s\n"), lookalike.Herb.Name, lookalike.Herb.ID, lookalike.Herb.ToxicityLabel())
+	if lookalike.Notes != "" {
+		fmt.Printf(i18n.T("    Как отличить: %s\n"), lookalike.Notes)
+	}
+}
+
+// parseHerbPair parses two herb IDs given as arguments
+func parseHerbPair(args []string) (int, int, error) {
+	ids := make([]int, 2)
+	for i, arg := range args {
+		id, err := strconv.Atoi(arg)
+		if err != nil {
+			return 0, 0, i18n.Errorf("неверный ID: %s", arg)
+		}
+		ids[i] = id
+	}
+	return ids[0], ids[1], nil
+}
+
+func listLookAlikes(cmd *cobra.Command, args []string) error {
+	db := database.GetDB()
+	if db == nil {
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+	}
+	repos := repository.NewRepositories(db)
+
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return i18n.Errorf("неверный ID: %s", args[0])
+	}
+
+	herb, err := repos.Herbs.GetByID(id)
+	if err != nil {
+		return err
+	}
+	lookalikes, err := repos.LookAlikes.GetByHerb(id)
+	if err != nil {
+		return err
+	}
+
+	if len(lookalikes) == 0 {
+		fmt.Printf(i18n.T("Для травы «%s» похожие травы не указаны.\n"), herb.Name)
+		return nil
+	}
+
+	printLookAlikeWarning(herb, lookalikes)
+	fmt.Printf(i18n.T("Травы, похожие на «%s»:\n"), herb.Name)
+	for _, lookalike := range lookalikes {
+		printLookAlike(lookalike)
+	}
+	return nil
+}
+
+func addLookAlike(cmd *cobra.Command, args []string) error {
+	db := database.GetDB()
+	if db == nil {
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+	}
+	repos := repository.NewRepositories(db)
+
+	herbID, otherID, err := parseHerbPair(args)
+	if err != nil {
+		return err
+	}
+
+	herb, err := repos.Herbs.GetByID(herbID)
+	if err != nil {
+		return err
+	}
+	other, err := repos.Herbs.GetByID(otherID)
+	if err != nil {
+		return err
+	}
+
+	notes, _ := cmd.Flags().GetString("notes")
+	if err := repos.LookAlikes.Link(herb.ID, other.ID, strings.TrimSpace(notes)); err != nil {
+		return err
+	}
+
+	fmt.Printf(i18n.T("✅ Травы «%s» и «%s» отмечены как похожие\n"), herb.Name, other.Name)
+	return nil
+}
+
+func deleteLookAlike(cmd *cobra.Command, args []string) error {
+	db := database.GetDB()
+	if db == nil {
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+	}
+
+	herbID, otherID, err := parseHerbPair(args)
+	if err != nil {
+		return err
+	}
+
+	if err := repository.NewLookAlikeRepository(db).Unlink(herbID, otherID); err != nil {
+		return err
+	}
+
+	fmt.Printf(i18n.T("✅ Отметка о сходстве трав с ID %d и %d удалена\n"), herbID, otherID)
+	return nil
+}
@@ -0,0 +1,251 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gloowl/simple_crud/src/internal/database"
+	"github.com/gloowl/simple_crud/src/internal/dedup"
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+	"github.com/gloowl/simple_crud/src/internal/models"
+	"github.com/gloowl/simple_crud/src/internal/repository"
+
+	"github.com/spf13/cobra"
+)
+
+// duplicatesHerbCmd reports likely duplicate herbs
+var duplicatesHerbCmd = &cobra.Command{
+	Use:     "duplicates",
+	Aliases: []string{"dupes"},
+	Short:   "Найти возможные дубликаты трав",
+	Long: `Группирует травы, которые похожи на дубликаты: с одинаковым названием или
+латинским названием (без учета регистра и пробелов), а также с латинскими
+названиями, отличающимися опечаткой.`,
+	Example: `  herbs-cli herb duplicates
+  herbs-cli herb duplicates --distance 1`,
+	RunE: findDuplicateHerbs,
+}
+
+// mergeHerbCmd merges two herb records
+var mergeHerbCmd = &cobra.Command{
+	Use:   "merge KEEP_ID DROP_ID",
+	Short: "Объединить две записи о траве",
+	Long: `Объединяет траву DROP_ID с травой KEEP_ID: регионы и способы применения
+переносятся на KEEP_ID, после чего запись DROP_ID удаляется. Все изменения
+выполняются в одной транзакции.
+
+Пустые поля KEEP_ID заполняются значениями из DROP_ID. Трава считается ядовитой,
+если ядовитой отмечена хотя бы одна из записей. Остальные расхождения решаются
+интерактивно или правилом --prefer:
+  keep     - оставить значение KEEP_ID
+  drop     - взять значение DROP_ID
+  longest  - взять более длинное значение`,
+	Args: cobra.ExactArgs(2),
+	Example: `  herbs-cli herb merge 3 7
+  herbs-cli herb merge 3 7 --prefer longest --yes`,
+	RunE: mergeHerbs,
+}
+
+// mergeField gives access to a text field that is resolved during a merge
+type mergeField struct {
+	name string
+	get  func(h *models.Herb) string
+	set  func(h *models.Herb, value string)
+}
+
+var mergeFields = []mergeField{
+	{"name", func(h *models.Herb) string { return h.Name }, func(h *models.Herb, v string) { h.Name = v }},
+	{"latin_name", func(h *models.Herb) string { return h.LatinName }, func(h *models.Herb, v string) { h.LatinName = v }},
+	{"description", func(h *models.Herb) string { return h.Description }, func(h *models.Herb, v string) { h.Description = v }},
+	{"image_path", func(h *models.Herb) string { return h.ImagePath }, func(h *models.Herb, v string) { h.ImagePath = v }},
+}
+
+func init() {
+	herbCmd.AddCommand(duplicatesHerbCmd)
+	herbCmd.AddCommand(mergeHerbCmd)
+
+	duplicatesHerbCmd.Flags().Int("distance", 2, "максимальное число опечаток в латинском названии (0 - только точные совпадения)")
+
+	mergeHerbCmd.Flags().String("prefer", "", "правило разрешения конфликтов: keep, drop или longest")
+	mergeHerbCmd.Flags().BoolP("yes", "y", false, "объединить без подтверждения")
+}
+
+func findDuplicateHerbs(cmd *cobra.Command, args []string) error {
+	db := database.GetDB()
+	if db == nil {
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+	}
+	herbRepo := repository.NewHerbRepository(db)
+
+	herbs, err := herbRepo.GetAll()
+	if err != nil {
+		return i18n.Errorf("не удалось получить список трав: %v", err)
+	}
+
+	distance, _ := cmd.Flags().GetInt("distance")
+	groups := dedup.FindDuplicates(herbs, distance)
+
+	if len(groups) == 0 {
+		fmt.Println(i18n.T("Возможных дубликатов не найдено."))
+		return nil
+	}
+
+	fmt.Printf(i18n.T("Найдено групп возможных дубликатов: %d\n"), len(groups))
+
+	for i, group := range groups {
+		fmt.Printf(i18n.T("\nГруппа %d:\n"), i+1)
+		for _, herb := range group.Herbs {
+			fmt.Printf("  ID %-4d %s\n", herb.ID, describeHerb(&herb))
+		}
+		for _, reason := range group.Reasons {
+			fmt.Printf("    - %s\n", reason)
+		}
+	}
+
+	fmt.Println(i18n.T("\nДля объединения используйте 'herb merge KEEP_ID DROP_ID'."))
+	return nil
+}
+
+func mergeHerbs(cmd *cobra.Command, args []string) error {
+	db := database.GetDB()
+	if db == nil {
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+	}
+	herbRepo := repository.NewHerbRepository(db)
+
+	keepID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return i18n.Errorf("неверный ID: %s", args[0])
+	}
+	dropID, err := strconv.Atoi(args[1])
+	if err != nil {
+		return i18n.Errorf("неверный ID: %s", args[1])
+	}
+	if keepID == dropID {
+		return i18n.Errorf("нельзя объединить траву саму с собой")
+	}
+
+	prefer, _ := cmd.Flags().GetString("prefer")
+	switch prefer {
+	case "", "keep", "drop", "longest":
+	default:
+		return i18n.Errorf("неизвестное правило --prefer: %s (доступно: keep, drop, longest)", prefer)
+	}
+	if prefer == "" && !isInteractive() {
+		return i18n.Errorf("ввод не является терминалом: укажите правило разрешения конфликтов через --prefer")
+	}
+
+	keep, err := herbRepo.GetByID(keepID)
+	if err != nil {
+		return err
+	}
+	drop, err := herbRepo.GetByID(dropID)
+	if err != nil {
+		return err
+	}
+
+	merged := *keep
+	resolveMergeFields(&merged, keep, drop, prefer)
+
+	fmt.Printf(i18n.T("Трава ID %d будет объединена с травой ID %d и удалена.\n"), drop.ID, keep.ID)
+	if changes := diffHerbs(keep, &merged); len(changes) > 0 {
+		fmt.Printf(i18n.T("Изменения в траве ID %d:\n"), keep.ID)
+		for _, change := range changes {
+			fmt.Printf("  %s\n", change)
+		}
+	}
+
+	if yes, _ := cmd.Flags().GetBool("yes"); !yes {
+		fmt.Println()
+		ok, err := confirm(i18n.T("Вы уверены?"))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println(i18n.T("Объединение отменено."))
+			return nil
+		}
+	}
+
+	merged.UpdatedBy = identity
+	err = repository.NewUnitOfWork(db).Do(func(repos *repository.Repositories) error {
+		if err := repos.Herbs.MoveLinks(drop.ID, keep.ID); err != nil {
+			return err
+		}
+		// The dropped herb goes first so that the kept one may take over its name
+		if err := repos.Herbs.Delete(drop.ID); err != nil {
+			return err
+		}
+		return repos.Herbs.Update(&merged)
+	})
+	if err != nil {
+		return i18n.Errorf("не удалось объединить травы: %v", err)
+	}
+
+	fmt.Printf(i18n.T("✅ Трава с ID %d объединена с травой ID %d\n"), drop.ID, keep.ID)
+	fmt.Println(merged.String())
+	return nil
+}
+
+// resolveMergeFields fills merged with the values chosen from keep and drop.
+// An empty prefer rule asks the user about every conflicting field.
+func resolveMergeFields(merged, keep, drop *models.Herb, prefer string) {
+	for _, field := range mergeFields {
+		keepValue, dropValue := field.get(keep), field.get(drop)
+		if dropValue == "" || dropValue == keepValue {
+			continue
+		}
+		if keepValue == "" {
+			field.set(merged, dropValue)
+			continue
+		}
+
+		switch prefer {
+		case "keep":
+		case "drop":
+			field.set(merged, dropValue)
+		case "longest":
+			if utf8.RuneCountInString(dropValue) > utf8.RuneCountInString(keepValue) {
+				field.set(merged, dropValue)
+			}
+		default:
+			if askMergeChoice(field.name, keep, drop, keepValue, dropValue) == 2 {
+				field.set(merged, dropValue)
+			}
+		}
+	}
+
+	// Erring on the side of caution: the merged herb keeps the higher toxicity
+	// and every toxic part, compound and symptom of both records
+	merged.SetToxicity(max(keep.Toxicity, drop.Toxicity))
+	merged.ToxicParts = mergeLists(keep.ToxicParts, drop.ToxicParts)
+	merged.ToxicCompounds = mergeLists(keep.ToxicCompounds, drop.ToxicCompounds)
+	merged.ToxicitySymptoms = mergeLists(keep.ToxicitySymptoms, drop.ToxicitySymptoms)
+
+	// A herb flowers and is harvested in any of the months known for either record
+	merged.FloweringMonths = append(append(models.Months{}, keep.FloweringMonths...), drop.FloweringMonths...).Normalize()
+	merged.HarvestMonths = append(append(models.Months{}, keep.HarvestMonths...), drop.HarvestMonths...).Normalize()
+}
+
+// askMergeChoice asks which of two conflicting values to keep and returns 1 or 2
+func askMergeChoice(field string, keep, drop *models.Herb, keepValue, dropValue string) int {
+	fmt.Printf(i18n.T("\nПоле %s различается:\n"), field)
+	fmt.Printf("  1) [ID %d] %s\n", keep.ID, strings.TrimSpace(keepValue))
+	fmt.Printf("  2) [ID %d] %s\n", drop.ID, strings.TrimSpace(dropValue))
+
+	for {
+		fmt.Print(i18n.T("Выберите значение (1/2) [1]: "))
+
+		var answer string
+		fmt.Scanln(&answer)
+
+		switch strings.TrimSpace(answer) {
+		case "", "1":
+			return 1
+		case "2":
+			return 2
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gloowl/simple_crud/src/internal/database"
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+	"github.com/gloowl/simple_crud/src/internal/models"
+	"github.com/gloowl/simple_crud/src/internal/repository"
+
+	"github.com/spf13/cobra"
+)
+
+// herbNameCmd groups the commands for alternative herb names
+var herbNameCmd = &cobra.Command{
+	Use:   "name",
+	Short: "Другие названия травы",
+	Long: `Народные названия на разных языках и устаревшие научные синонимы травы.
+Поиск (herb search) находит траву по любому из ее названий.`,
+}
+
+// addHerbNameCmd adds an alternative name to a herb
+var addHerbNameCmd = &cobra.Command{
+	Use:   "add HERB_ID NAME",
+	Short: "Добавить название травы",
+	Long: `Добавляет траве другое название.
+
+Типы названий:
+  vernacular  - народное название (по умолчанию)
+  synonym     - устаревший научный синоним
+  accepted    - принятое научное название`,
+	Args: cobra.ExactArgs(2),
+	Example: `  herbs-cli herb name add 1 "Аптечная ромашка"
+  herbs-cli herb name add 1 "Chamomile" --lang en
+  herbs-cli herb name add 1 "Chamomilla recutita" --lang la --type synonym`,
+	RunE: addHerbName,
+}
+
+// deleteHerbNameCmd removes an alternative name
+var deleteHerbNameCmd = &cobra.Command{
+	Use:   "delete NAME_ID",
+	Short: "Удалить название травы",
+	Args:  cobra.ExactArgs(1),
+	RunE:  deleteHerbName,
+}
+
+func init() {
+	herbCmd.AddCommand(herbNameCmd)
+	herbNameCmd.AddCommand(addHerbNameCmd)
+	herbNameCmd.AddCommand(deleteHerbNameCmd)
+
+	addHerbNameCmd.Flags().String("lang", "ru", "код языка: ru, en, la и т.п.")
+	addHerbNameCmd.Flags().String("type", models.NameVernacular, "тип названия: vernacular, synonym или accepted")
+}
+
+// printHerbNames prints the alternative names of a herb grouped by language
+func printHerbNames(names []models.HerbName) {
+	if len(names) == 0 {
+		return
+	}
+
+	fmt.Println(i18n.T("Другие названия:"))
+	for i := 0; i < len(names); {
+		language := names[i].Language
+		var items []string
+		for ; i < len(names) && names[i].Language == language; i++ {
+			item := names[i].Name
+			if names[i].Type != models.NameVernacular {
+				item += " (" + names[i].TypeLabel() + ")"
+			}
+			items = append(items, fmt.Sprintf("%s [%d]", item, names[i].ID))
+		}
+		fmt.Printf("  %s: %s\n", models.LanguageLabel(language), strings.Join(items, ", "))
+	}
+}
+
+func addHerbName(cmd *cobra.Command, args []string) error {
+	db := database.GetDB()
+	if db == nil {
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+	}
+	repos := repository.NewRepositories(db)
+
+	herbID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return i18n.Errorf("неверный ID: %s", args[0])
+	}
+	herb, err := repos.Herbs.GetByID(herbID)
+	if err != nil {
+		return err
+	}
+
+	language, _ := cmd.Flags().GetString("lang")
+	nameType, _ := cmd.Flags().GetString("type")
+
+	name := &models.HerbName{
+		HerbID:   herb.ID,
+		Name:     strings.Join(strings.Fields(args[1]), " "),
+		Language: strings.ToLower(strings.TrimSpace(language)),
+		Type:     strings.ToLower(strings.TrimSpace(nameType)),
+	}
+	if err := repos.Names.Create(name); err != nil {
+		return i18n.Errorf("не удалось добавить название: %w", err)
+	}
+
+	fmt.Printf(i18n.T("✅ Траве «%s» добавлено название «%s» с ID: %d\n"), herb.Name, name.Name, name.ID)
+	return nil
+}
+
+func deleteHerbName(cmd *cobra.Command, args []string) error {
+	db := database.GetDB()
+	if db == nil {
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+	}
+
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return i18n.Errorf("неверный ID: %s", args[0])
+	}
+
+	if err := repository.NewHerbNameRepository(db).Delete(id); err != nil {
+		return err
+	}
+
+	fmt.Printf(i18n.T("✅ Название с ID %d удалено\n"), id)
+	return nil
+}
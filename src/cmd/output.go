@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+	"github.com/gloowl/simple_crud/src/internal/models"
+
+	"github.com/spf13/cobra"
+)
+
+// addOutputFlag registers the --output and --format flags shared by commands that print herbs
+func addOutputFlag(cmd *cobra.Command) {
+	cmd.Flags().StringP("output", "o", "text", "формат вывода (text, json, csv)")
+	cmd.Flags().String("format", "", "шаблон Go text/template для каждой травы или имя шаблона из каталога шаблонов")
+}
+
+// printMachineOutput writes herbs in the machine-readable format selected with --output,
+// or with the template given in --format. It returns false when the human-readable
+// text format was requested, leaving the output to the caller. With single set,
+// JSON output is an object instead of an array.
+func printMachineOutput(cmd *cobra.Command, herbs []models.Herb, single bool) (bool, error) {
+	format, _ := cmd.Flags().GetString("output")
+
+	if tmplText, _ := cmd.Flags().GetString("format"); tmplText != "" {
+		if cmd.Flags().Changed("output") {
+			return true, i18n.Errorf("флаги --format и --output нельзя использовать вместе")
+		}
+		tmpl, err := parseFormat(tmplText)
+		if err != nil {
+			return true, err
+		}
+		return true, writeHerbsTemplate(os.Stdout, tmpl, herbs)
+	}
+
+	switch format {
+	case "", "text":
+		return false, nil
+	case "json":
+		return true, writeHerbsJSON(os.Stdout, herbs, single)
+	case "csv":
+		return true, writeHerbsCSV(os.Stdout, herbs)
+	default:
+		return true, i18n.Errorf("неизвестный формат вывода: %s (доступно: text, json, csv)", format)
+	}
+}
+
+// machineTime converts t to the display timezone with second precision,
+// so that it is encoded as RFC 3339
+func machineTime(t time.Time) time.Time {
+	return t.In(models.DisplayLocation()).Truncate(time.Second)
+}
+
+func writeHerbsJSON(w io.Writer, herbs []models.Herb, single bool) error {
+	out := make([]models.Herb, len(herbs))
+	for i, herb := range herbs {
+		herb.CreatedAt = machineTime(herb.CreatedAt)
+		herb.UpdatedAt = machineTime(herb.UpdatedAt)
+		out[i] = herb
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if single && len(out) == 1 {
+		return encoder.Encode(out[0])
+	}
+	return encoder.Encode(out)
+}
+
+func writeHerbsCSV(w io.Writer, herbs []models.Herb) error {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"id", "name", "latin_name", "description", "is_poisonous",
+		"image_path", "created_at", "updated_at", "created_by", "updated_by",
+		"genus", "species", "infra_rank", "infra_epithet", "authorship",
+		"toxicity", "toxic_parts", "toxic_compounds", "toxicity_symptoms",
+		"flowering_months", "harvest_months"})
+
+	for _, herb := range herbs {
+		writer.Write([]string{
+			strconv.Itoa(herb.ID),
+			herb.Name,
+			herb.LatinName,
+			herb.Description,
+			strconv.FormatBool(herb.IsPoisonous),
+			herb.ImagePath,
+			machineTime(herb.CreatedAt).Format(time.RFC3339),
+			machineTime(herb.UpdatedAt).Format(time.RFC3339),
+			herb.CreatedBy,
+			herb.UpdatedBy,
+			herb.Genus,
+			herb.Species,
+			herb.InfraRank,
+			herb.InfraEpithet,
+			herb.Authorship,
+			herb.Toxicity.String(),
+			strings.Join(herb.ToxicParts, ";"),
+			strings.Join(herb.ToxicCompounds, ";"),
+			strings.Join(herb.ToxicitySymptoms, ";"),
+			herb.FloweringMonths.String(),
+			herb.HarvestMonths.String(),
+		})
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
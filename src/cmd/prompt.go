@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+
+	"golang.org/x/term"
+)
+
+// isInteractive reports whether standard input is a terminal
+func isInteractive() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// confirm asks a yes/no question and reports whether the user agreed.
+// Without a terminal there is nobody to answer, so an error is returned
+// instead of silently treating the missing answer as "no".
+func confirm(question string) (bool, error) {
+	if !isInteractive() {
+		return false, i18n.Errorf("требуется подтверждение, но ввод не является терминалом; используйте --yes")
+	}
+
+	fmt.Printf("%s (y/N): ", question)
+
+	var confirmation string
+	fmt.Scanln(&confirmation)
+
+	return confirmation == "y" || confirmation == "Y", nil
+}
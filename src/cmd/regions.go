@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gloowl/simple_crud/src/internal/database"
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+	"github.com/gloowl/simple_crud/src/internal/models"
+	"github.com/gloowl/simple_crud/src/internal/repository"
+
+	"github.com/spf13/cobra"
+)
+
+// regionCmd groups the commands for regions
+var regionCmd = &cobra.Command{
+	Use:   "region",
+	Short: "Регионы произрастания трав",
+	Long: `Регионы, в которых растут травы. Регионы образуют иерархию, например
+Россия → Сибирь → Алтайский край: травы региона включают травы всех его подрегионов.
+
+Трава привязывается к региону командой herb season.`,
+}
+
+// addRegionCmd adds a region
+var addRegionCmd = &cobra.Command{
+	Use:   "add NAME",
+	Short: "Добавить регион",
+	Args:  cobra.ExactArgs(1),
+	Example: `  herbs-cli region add Россия
+  herbs-cli region add Сибирь --parent Россия
+  herbs-cli region add "Алтайский край" --parent Сибирь --desc "Юг Западной Сибири"`,
+	RunE: addRegion,
+}
+
+// listRegionsCmd lists all regions
+var listRegionsCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Показать все регионы",
+	RunE:  listRegions,
+}
+
+// regionTreeCmd prints the region hierarchy
+var regionTreeCmd = &cobra.Command{
+	Use:   "tree",
+	Short: "Показать дерево регионов",
+	Long: `Выводит иерархию регионов с количеством трав в каждом регионе
+(включая травы его подрегионов; трава, растущая в нескольких подрегионах,
+считается один раз).`,
+	RunE: printRegionTree,
+}
+
+// moveRegionCmd changes the parent of a region
+var moveRegionCmd = &cobra.Command{
+	Use:   "move REGION",
+	Short: "Изменить родительский регион",
+	Long: `Переносит регион (ID или название) в регион из --parent или, с --root,
+делает его регионом верхнего уровня. Регион нельзя перенести в него самого
+или в один из его подрегионов.`,
+	Args: cobra.ExactArgs(1),
+	Example: `  herbs-cli region move "Алтайский край" --parent Сибирь
+  herbs-cli region move Сибирь --root`,
+	RunE: moveRegion,
+}
+
+func init() {
+	rootCmd.AddCommand(regionCmd)
+	regionCmd.AddCommand(addRegionCmd)
+	regionCmd.AddCommand(listRegionsCmd)
+	regionCmd.AddCommand(regionTreeCmd)
+	regionCmd.AddCommand(moveRegionCmd)
+
+	addRegionCmd.Flags().String("parent", "", "родительский регион (ID или название)")
+	addRegionCmd.Flags().StringP("desc", "d", "", "описание региона")
+
+	listRegionsCmd.Flags().StringP("output", "o", "text", "формат вывода (text, json)")
+
+	moveRegionCmd.Flags().String("parent", "", "новый родительский регион (ID или название)")
+	moveRegionCmd.Flags().Bool("root", false, "сделать регион регионом верхнего уровня")
+}
+
+// resolveRegion finds a region by ID or by name
+func resolveRegion(repo *repository.RegionRepository, arg string) (*models.Region, error) {
+	if id, err := strconv.Atoi(arg); err == nil {
+		return repo.GetByID(id)
+	}
+	return repo.GetByName(strings.TrimSpace(arg))
+}
+
+// regionLink describes a region for buildTree
+func regionLink(region models.Region) (int, *int, int) {
+	return region.ID, region.ParentID, region.HerbCount
+}
+
+// regionLine formats a region of the tree with the herbs linked to the region itself
+func regionLine(node *treeNode[models.Region]) string {
+	return fmt.Sprintf(i18n.T("%s [%d] — трав: %d"), node.item.Name, node.item.ID, node.item.HerbCount)
+}
+
+func addRegion(cmd *cobra.Command, args []string) error {
+	db := database.GetDB()
+	if db == nil {
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+	}
+	repo := repository.NewRegionRepository(db)
+
+	description, _ := cmd.Flags().GetString("desc")
+	region := &models.Region{
+		Name:        strings.Join(strings.Fields(args[0]), " "),
+		Description: strings.TrimSpace(description),
+	}
+	if parentArg, _ := cmd.Flags().GetString("parent"); strings.TrimSpace(parentArg) != "" {
+		parent, err := resolveRegion(repo, parentArg)
+		if err != nil {
+			return err
+		}
+		region.ParentID = &parent.ID
+	}
+
+	if err := repo.Create(region); err != nil {
+		return i18n.Errorf("не удалось добавить регион: %w", err)
+	}
+
+	fmt.Printf(i18n.T("✅ Регион «%s» добавлен с ID: %d\n"), region.Name, region.ID)
+	return nil
+}
+
+func listRegions(cmd *cobra.Command, args []string) error {
+	db := database.GetDB()
+	if db == nil {
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+	}
+
+	regions, err := repository.NewRegionRepository(db).GetAll()
+	if err != nil {
+		return err
+	}
+
+	switch format, _ := cmd.Flags().GetString("output"); format {
+	case "", "text":
+	case "json":
+		if regions == nil {
+			regions = []models.Region{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(regions)
+	default:
+		return i18n.Errorf("неизвестный формат вывода: %s (доступно: text, json)", format)
+	}
+
+	if len(regions) == 0 {
+		fmt.Println(i18n.T("Регионы не найдены"))
+		return nil
+	}
+
+	names := make(map[int]string, len(regions))
+	for _, region := range regions {
+		names[region.ID] = region.Name
+	}
+	for _, region := range regions {
+		line := fmt.Sprintf("[%d] %s", region.ID, region.Name)
+		if region.ParentID != nil {
+			line += fmt.Sprintf(i18n.T(" (в регионе «%s»)"), names[*region.ParentID])
+		}
+		if region.Description != "" {
+			line += " — " + region.Description
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
+
+func printRegionTree(cmd *cobra.Command, args []string) error {
+	db := database.GetDB()
+	if db == nil {
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+	}
+
+	regions, err := repository.NewRegionRepository(db).GetTree()
+	if err != nil {
+		return i18n.Errorf("не удалось получить регионы: %v", err)
+	}
+
+	if len(regions) == 0 {
+		fmt.Println(i18n.T("Регионы не найдены. Добавьте их командой 'region add'."))
+		return nil
+	}
+
+	writeTree(buildTree(regions, regionLink), regionLine)
+	return nil
+}
+
+func moveRegion(cmd *cobra.Command, args []string) error {
+	db := database.GetDB()
+	if db == nil {
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+	}
+	repo := repository.NewRegionRepository(db)
+
+	parentArg, _ := cmd.Flags().GetString("parent")
+	root, _ := cmd.Flags().GetBool("root")
+	parentArg = strings.TrimSpace(parentArg)
+	if (parentArg == "") == !root {
+		return i18n.Errorf("укажите ровно один из флагов --parent и --root")
+	}
+
+	region, err := resolveRegion(repo, args[0])
+	if err != nil {
+		return err
+	}
+
+	var parent *models.Region
+	if parentArg != "" {
+		if parent, err = resolveRegion(repo, parentArg); err != nil {
+			return err
+		}
+		if err := repo.SetParent(region.ID, &parent.ID); err != nil {
+			return err
+		}
+		fmt.Printf(i18n.T("✅ Регион «%s» перенесен в регион «%s»\n"), region.Name, parent.Name)
+		return nil
+	}
+
+	if err := repo.SetParent(region.ID, nil); err != nil {
+		return err
+	}
+	fmt.Printf(i18n.T("✅ Регион «%s» стал регионом верхнего уровня\n"), region.Name)
+	return nil
+}
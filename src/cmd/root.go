@@ -3,8 +3,12 @@ package cmd
 import (
 	"fmt"
 	"github.com/gloowl/simple_crud/src/internal/database"
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+	"github.com/gloowl/simple_crud/src/internal/models"
 	"log"
 	"os"
+	"os/user"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -13,6 +17,10 @@ import (
 var (
 	cfgFile  string
 	dbConfig database.Config
+	identity string
+	timezone string
+	lang     string
+	plain    bool
 )
 
 // rootCmd represents the base command
@@ -30,23 +38,54 @@ var rootCmd = &cobra.Command{
 - Поиск трав по названию`,
 
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if err := applyLanguage(cmd.Root()); err != nil {
+			fmt.Printf(i18n.T("Ошибка настройки языка: %v\n"), err)
+			os.Exit(1)
+		}
+
+		if err := applyTimezone(); err != nil {
+			fmt.Printf(i18n.T("Ошибка настройки часового пояса: %v\n"), err)
+			os.Exit(1)
+		}
+
+		// Commands run from the shell reuse its connection
+		if inShell {
+			return
+		}
+
 		// Connect to database before running any command
 		if err := database.Connect(dbConfig); err != nil {
-			fmt.Printf("Ошибка подключения к базе данных: %v\n", err)
+			fmt.Printf(i18n.T("Ошибка подключения к базе данных: %v\n"), err)
 			os.Exit(1)
 		}
 	},
 
 	PersistentPostRun: func(cmd *cobra.Command, args []string) {
+		if inShell {
+			return
+		}
+
 		// Close database connection after running command
 		if err := database.Close(); err != nil {
-			log.Printf("Ошибка закрытия соединения с БД: %v", err)
+			log.Printf(i18n.T("Ошибка закрытия соединения с БД: %v"), err)
 		}
 	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately
 func Execute() {
+	// Help is shown without running PersistentPreRun, so the language is applied here
+	defaultHelp := rootCmd.HelpFunc()
+	rootCmd.SetHelpFunc(func(cmd *cobra.Command, args []string) {
+		applyLanguage(cmd.Root())
+		defaultHelp(cmd, args)
+	})
+	defaultUsage := rootCmd.UsageFunc()
+	rootCmd.SetUsageFunc(func(cmd *cobra.Command) error {
+		applyLanguage(cmd.Root())
+		return defaultUsage(cmd)
+	})
+
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
@@ -58,6 +97,10 @@ func init() {
 
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "файл конфигурации (по умолчанию $HOME/.herbs-cli.yaml)")
+	rootCmd.PersistentFlags().StringVar(&timezone, "tz", "", "часовой пояс для вывода дат, например Europe/Moscow или UTC (по умолчанию локальный)")
+	rootCmd.PersistentFlags().StringVar(&identity, "identity", defaultIdentity(), "имя, под которым сохраняются изменения записей")
+	rootCmd.PersistentFlags().StringVar(&lang, "lang", "", "язык сообщений: ru или en (по умолчанию определяется по LANG)")
+	rootCmd.PersistentFlags().BoolVar(&plain, "plain", false, "вывод без эмодзи и декоративных символов")
 
 	// Database connection flags (используем вашу конфигурацию по умолчанию)
 	rootCmd.PersistentFlags().StringVar(&dbConfig.Host, "host", "localhost", "адрес сервера PostgreSQL")
@@ -74,10 +117,42 @@ func init() {
 	viper.BindPFlag("password", rootCmd.PersistentFlags().Lookup("password"))
 	viper.BindPFlag("dbname", rootCmd.PersistentFlags().Lookup("dbname"))
 	viper.BindPFlag("sslmode", rootCmd.PersistentFlags().Lookup("sslmode"))
+	viper.BindPFlag("identity", rootCmd.PersistentFlags().Lookup("identity"))
+	viper.BindPFlag("tz", rootCmd.PersistentFlags().Lookup("tz"))
+	viper.BindPFlag("lang", rootCmd.PersistentFlags().Lookup("lang"))
+	viper.BindPFlag("plain", rootCmd.PersistentFlags().Lookup("plain"))
+}
+
+// applyTimezone sets the timezone used to display timestamps from the --tz flag
+func applyTimezone() error {
+	if timezone == "" {
+		models.SetDisplayLocation(time.Local)
+		return nil
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return i18n.Errorf("неизвестный часовой пояс %q: %v", timezone, err)
+	}
+	models.SetDisplayLocation(loc)
+	return nil
+}
+
+// defaultIdentity returns the name of the OS user running the CLI
+func defaultIdentity() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	return os.Getenv("USER")
 }
 
 // initConfig reads in config file and ENV variables
 func initConfig() {
+	// The configuration was already read when the shell started
+	if inShell {
+		return
+	}
+
 	if cfgFile != "" {
 		// Use config file from the flag
 		viper.SetConfigFile(cfgFile)
@@ -100,7 +175,8 @@ func initConfig() {
 
 	// If a config file is found, read it in
 	if err := viper.ReadInConfig(); err == nil {
-		fmt.Printf("Используется конфигурационный файл: %s\n", viper.ConfigFileUsed())
+		// Report on stderr so that machine-readable output stays clean
+		fmt.Fprintf(os.Stderr, i18n.T("Используется конфигурационный файл: %s\n"), viper.ConfigFileUsed())
 
 		// Update database config from viper
 		dbConfig.Host = viper.GetString("host")
@@ -109,5 +185,9 @@ func initConfig() {
 		dbConfig.Password = viper.GetString("password")
 		dbConfig.DBName = viper.GetString("dbname")
 		dbConfig.SSLMode = viper.GetString("sslmode")
+		identity = viper.GetString("identity")
+		timezone = viper.GetString("tz")
+		lang = viper.GetString("lang")
+		plain = viper.GetBool("plain")
 	}
 }
@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gloowl/simple_crud/src/internal/database"
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+	"github.com/gloowl/simple_crud/src/internal/models"
+	"github.com/gloowl/simple_crud/src/internal/repository"
+
+	"github.com/spf13/cobra"
+)
+
+// contraindicationCmd groups the commands for herb contraindications
+var contraindicationCmd = &cobra.Command{
+	Use:     "contraindication",
+	Aliases: []string{"contra"},
+	Short:   "Противопоказания трав",
+	Long: `Противопоказания трав: беременность, детский возраст, заболевания.
+Противопоказания выводятся в herb get и проверяются командой check.`,
+}
+
+// addContraindicationCmd adds a contraindication to a herb
+var addContraindicationCmd = &cobra.Command{
+	Use:   "add HERB_ID CONDITION",
+	Short: "Добавить противопоказание",
+	Args:  cobra.ExactArgs(2),
+	Example: `  herbs-cli herb contraindication add 5 беременность --severity major
+  herbs-cli herb contraindication add 5 гипертония --notes "повышает давление"`,
+	RunE: addContraindication,
+}
+
+// deleteContraindicationCmd removes a contraindication
+var deleteContraindicationCmd = &cobra.Command{
+	Use:   "delete ID",
+	Short: "Удалить противопоказание",
+	Args:  cobra.ExactArgs(1),
+	RunE:  deleteContraindication,
+}
+
+// interactionCmd groups the commands for herb-drug interactions
+var interactionCmd = &cobra.Command{
+	Use:   "interaction",
+	Short: "Взаимодействия трав с лекарствами",
+	Long: `Известные взаимодействия трав с лекарствами и группами лекарств.
+Взаимодействия выводятся в herb get и проверяются командой check.`,
+}
+
+// addInteractionCmd adds an interaction to a herb
+var addInteractionCmd = &cobra.Command{
+	Use:   "add HERB_ID DRUG",
+	Short: "Добавить взаимодействие с лекарством",
+	Args:  cobra.ExactArgs(2),
+	Example: `  herbs-cli herb interaction add 7 варфарин --severity major --effect "ослабляет действие"
+  herbs-cli herb interaction add 7 антидепрессанты --effect "риск серотонинового синдрома"`,
+	RunE: addInteraction,
+}
+
+// deleteInteractionCmd removes an interaction
+var deleteInteractionCmd = &cobra.Command{
+	Use:   "delete ID",
+	Short: "Удалить взаимодействие",
+	Args:  cobra.ExactArgs(1),
+	RunE:  deleteInteraction,
+}
+
+func init() {
+	herbCmd.AddCommand(contraindicationCmd)
+	contraindicationCmd.AddCommand(addContraindicationCmd)
+	contraindicationCmd.AddCommand(deleteContraindicationCmd)
+
+	herbCmd.AddCommand(interactionCmd)
+	interactionCmd.AddCommand(addInteractionCmd)
+	interactionCmd.AddCommand(deleteInteractionCmd)
+
+	addContraindicationCmd.Flags().String("severity", models.SeverityModerate.String(), "степень серьезности: minor, moderate или major")
+	addContraindicationCmd.Flags().String("notes", "", "пояснение")
+	addInteractionCmd.Flags().String("severity", models.SeverityModerate.String(), "степень серьезности: minor, moderate или major")
+	addInteractionCmd.Flags().String("effect", "", "описание последствий взаимодействия")
+}
+
+// printHerbSafety prints the contraindications and interactions of a herb
+func printHerbSafety(contraindications []models.Contraindication, interactions []models.Interaction) {
+	if len(contraindications) > 0 {
+		fmt.Println(i18n.T("Противопоказания:"))
+		for _, c := range contraindications {
+			fmt.Printf("  [%d] %s (%s)%s\n", c.ID, c.Condition, c.Severity.Label(), withNote(c.Notes))
+		}
+	}
+	if len(interactions) > 0 {
+		fmt.Println(i18n.T("Взаимодействия с лекарствами:"))
+		for _, i := range interactions {
+			fmt.Printf("  [%d] %s (%s)%s\n", i.ID, i.Drug, i.Severity.Label(), withNote(i.Effect))
+		}
+	}
+}
+
+// withNote formats an optional note to be appended to a line
+func withNote(note string) string {
+	if note == "" {
+		return ""
+	}
+	return i18n.T(" — ") + note
+}
+
+// parseSeverityFlag reads the --severity flag of cmd
+func parseSeverityFlag(cmd *cobra.Command) (models.Severity, error) {
+	value, _ := cmd.Flags().GetString("severity")
+	return models.ParseSeverity(value)
+}
+
+func addContraindication(cmd *cobra.Command, args []string) error {
+	db := database.GetDB()
+	if db == nil {
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+	}
+	repos := repository.NewRepositories(db)
+
+	herbID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return i18n.Errorf("неверный ID: %s", args[0])
+	}
+	herb, err := repos.Herbs.GetByID(herbID)
+	if err != nil {
+		return err
+	}
+
+	severity, err := parseSeverityFlag(cmd)
+	if err != nil {
+		return err
+	}
+	notes, _ := cmd.Flags().GetString("notes")
+
+	c := &models.Contraindication{
+		HerbID:    herb.ID,
+		Condition: strings.Join(strings.Fields(args[1]), " "),
+		Severity:  severity,
+		Notes:     strings.TrimSpace(notes),
+	}
+	if err := repos.Contraindications.Create(c); err != nil {
+		return i18n.Errorf("не удалось добавить противопоказание: %w", err)
+	}
+
+	fmt.Printf(i18n.T("✅ Противопоказание «%s» для травы «%s» добавлено с ID: %d\n"), c.Condition, herb.Name, c.ID)
+	return nil
+}
+
+func deleteContraindication(cmd *cobra.Command, args []string) error {
+	db := database.GetDB()
+	if db == nil {
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+	}
+
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return i18n.Errorf("неверный ID: %s", args[0])
+	}
+
+	if err := repository.NewContraindicationRepository(db).Delete(id); err != nil {
+		return err
+	}
+
+	fmt.Printf(i18n.T("✅ Противопоказание с ID %d удалено\n"), id)
+	return nil
+}
+
+func addInteraction(cmd *cobra.Command, args []string) error {
+	db := database.GetDB()
+	if db == nil {
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+	}
+	repos := repository.NewRepositories(db)
+
+	herbID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return i18n.Errorf("неверный ID: %s", args[0])
+	}
+	herb, err := repos.Herbs.GetByID(herbID)
+	if err != nil {
+		return err
+	}
+
+	severity, err := parseSeverityFlag(cmd)
+	if err != nil {
+		return err
+	}
+	effect, _ := cmd.Flags().GetString("effect")
+
+	interaction := &models.Interaction{
+		HerbID:   herb.ID,
+		Drug:     strings.Join(strings.Fields(args[1]), " "),
+		Severity: severity,
+		Effect:   strings.TrimSpace(effect),
+	}
+	if err := repos.Interactions.Create(interaction); err != nil {
+		return i18n.Errorf("не удалось добавить взаимодействие: %w", err)
+	}
+
+	fmt.Printf(i18n.T("✅ Взаимодействие травы «%s» с «%s» добавлено с ID: %d\n"), herb.Name, interaction.Drug, interaction.ID)
+	return nil
+}
+
+func deleteInteraction(cmd *cobra.Command, args []string) error {
+	db := database.GetDB()
+	if db == nil {
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+	}
+
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return i18n.Errorf("неверный ID: %s", args[0])
+	}
+
+	if err := repository.NewInteractionRepository(db).Delete(id); err != nil {
+		return err
+	}
+
+	fmt.Printf(i18n.T("✅ Взаимодействие с ID %d удалено\n"), id)
+	return nil
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"github.com/gloowl/simple_crud/src/internal/models"
+
+	"github.com/spf13/cobra"
+)
+
+// addSeasonFlags registers the flags with the months of flowering and harvest of a herb
+func addSeasonFlags(cmd *cobra.Command) {
+	cmd.Flags().String("flowering", "", "месяцы цветения, например 6-8 или июн-авг")
+	cmd.Flags().String("harvest", "", "месяцы сбора, например 7,8 или 11-2")
+}
+
+// applySeasonFlags copies the season flags that were given to herb
+func applySeasonFlags(cmd *cobra.Command, herb *models.Herb) error {
+	flowering, harvest, err := seasonFlags(cmd)
+	if err != nil {
+		return err
+	}
+	if flowering != nil {
+		herb.FloweringMonths = flowering
+	}
+	if harvest != nil {
+		herb.HarvestMonths = harvest
+	}
+	return nil
+}
+
+// seasonFlags parses the season flags; the months of a flag that was not given are nil
+func seasonFlags(cmd *cobra.Command) (flowering, harvest models.Months, err error) {
+	if cmd.Flags().Changed("flowering") {
+		value, _ := cmd.Flags().GetString("flowering")
+		if flowering, err = models.ParseMonths(value); err != nil {
+			return nil, nil, err
+		}
+	}
+	if cmd.Flags().Changed("harvest") {
+		value, _ := cmd.Flags().GetString("harvest")
+		if harvest, err = models.ParseMonths(value); err != nil {
+			return nil, nil, err
+		}
+	}
+	return flowering, harvest, nil
+}
@@ -0,0 +1,613 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gloowl/simple_crud/src/internal/database"
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+	"github.com/gloowl/simple_crud/src/internal/models"
+	"github.com/gloowl/simple_crud/src/internal/repository"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/term"
+)
+
+// inShell is set while the interactive shell runs, so that the commands it
+// executes reuse the shell's database connection instead of opening their own
+var inShell bool
+
+// shellHistoryLimit is how many lines of history are kept between sessions
+const shellHistoryLimit = 500
+
+// shellCmd starts the interactive shell
+var shellCmd = &cobra.Command{
+	Use:   "shell",
+	Short: "Интерактивная оболочка",
+	Long: `Запускает интерактивную оболочку с одним постоянным подключением к базе данных.
+В оболочке доступны все команды herb (слово "herb" можно не писать), история
+команд (стрелки вверх и вниз) и дополнение команд и названий трав по Tab.
+
+Команды оболочки:
+  use ID|название  - выбрать текущую траву; get, update, edit и delete без ID
+                     работают с ней, а символ @ заменяется ее ID
+  unuse            - сбросить текущую траву
+  help             - показать справку
+  exit, quit       - выйти (или Ctrl+D)`,
+	Example: `  herbs-cli shell
+  herbs> use 3
+  herbs [3 Ромашка]> get
+  herbs [3 Ромашка]> update --desc "Новое описание"
+  herbs [3 Ромашка]> list -t`,
+	Args: cobra.NoArgs,
+	RunE: runShell,
+}
+
+// shell holds the state of an interactive session
+type shell struct {
+	terminal  *term.Terminal
+	history   *shellHistory
+	current   *models.Herb
+	herbNames []string
+
+	// globals holds the global flags as they were when the shell started,
+	// including the values read from the configuration file
+	globals map[string]flagState
+}
+
+func init() {
+	rootCmd.AddCommand(shellCmd)
+}
+
+func runShell(cmd *cobra.Command, args []string) error {
+	if inShell {
+		return i18n.Errorf("оболочка уже запущена")
+	}
+	if !isInteractive() || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return i18n.Errorf("для оболочки нужен терминал")
+	}
+
+	inShell = true
+	defer func() { inShell = false }()
+
+	sh := &shell{history: loadShellHistory(), globals: saveFlags(rootCmd.PersistentFlags())}
+	defer sh.history.save()
+
+	screen := struct {
+		io.Reader
+		io.Writer
+	}{os.Stdin, os.Stdout}
+	sh.terminal = term.NewTerminal(screen, "")
+	sh.terminal.History = sh.history
+	sh.terminal.AutoCompleteCallback = sh.complete
+	sh.refreshHerbNames()
+
+	fmt.Println(i18n.T("Оболочка herbs-cli. Введите help для справки, exit для выхода."))
+
+	for {
+		line, err := sh.readLine()
+		if errors.Is(err, io.EOF) {
+			fmt.Println()
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		words, err := splitShellLine(line)
+		if err != nil {
+			fmt.Printf(i18n.T("❌ %v\n"), err)
+			continue
+		}
+		if len(words) == 0 {
+			continue
+		}
+
+		if done := sh.execute(words); done {
+			return nil
+		}
+	}
+}
+
+// readLine reads one line with the terminal in raw mode, which line editing needs
+func (sh *shell) readLine() (string, error) {
+	prompt := "herbs> "
+	if sh.current != nil {
+		prompt = fmt.Sprintf("herbs [%d %s]> ", sh.current.ID, sh.current.Name)
+	}
+	sh.terminal.SetPrompt(prompt)
+
+	fd := int(os.Stdin.Fd())
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return "", i18n.Errorf("не удалось перевести терминал в интерактивный режим: %v", err)
+	}
+	defer term.Restore(fd, state)
+
+	if width, height, err := term.GetSize(fd); err == nil {
+		sh.terminal.SetSize(width, height)
+	}
+	return sh.terminal.ReadLine()
+}
+
+// execute runs a single shell line and reports whether the shell should exit
+func (sh *shell) execute(words []string) bool {
+	switch words[0] {
+	case "exit", "quit":
+		return true
+	case "help", "?":
+		rootCmd.SetArgs(append([]string{"help"}, words[1:]...))
+		rootCmd.Execute()
+		return false
+	case "use":
+		sh.use(words[1:])
+		return false
+	case "unuse":
+		sh.current = nil
+		return false
+	case "shell":
+		fmt.Println(i18n.T("❌ оболочка уже запущена"))
+		return false
+	}
+
+	// Herb subcommands may be typed without the "herb" prefix
+	if found, _, err := herbCmd.Find(words); err == nil && found != herbCmd {
+		words = append([]string{"herb"}, words...)
+	}
+	words = sh.withCurrentHerb(words)
+
+	resetFlags(rootCmd)
+	restoreFlags(rootCmd.PersistentFlags(), sh.globals)
+	rootCmd.SetArgs(words)
+	rootCmd.Execute()
+
+	sh.refreshHerbNames()
+	sh.refreshCurrent()
+	return false
+}
+
+// use selects the current herb by ID or exact name
+func (sh *shell) use(args []string) {
+	if len(args) == 0 {
+		if sh.current == nil {
+			fmt.Println(i18n.T("Текущая трава не выбрана."))
+		} else {
+			fmt.Println(sh.current.String())
+		}
+		return
+	}
+
+	herbRepo := repository.NewHerbRepository(database.GetDB())
+	query := strings.Join(args, " ")
+
+	if id, err := strconv.Atoi(query); err == nil {
+		herb, err := herbRepo.GetByID(id)
+		if err != nil {
+			fmt.Printf(i18n.T("❌ %v\n"), err)
+			return
+		}
+		sh.current = herb
+		return
+	}
+
+	herbs, err := herbRepo.Search(query)
+	if err != nil {
+		fmt.Printf(i18n.T("❌ %v\n"), err)
+		return
+	}
+
+	var matches []models.Herb
+	for _, herb := range herbs {
+		if strings.EqualFold(herb.Name, query) || strings.EqualFold(herb.LatinName, query) {
+			matches = append(matches, herb)
+		}
+	}
+	if len(matches) == 0 {
+		matches = herbs
+	}
+
+	switch len(matches) {
+	case 0:
+		fmt.Printf(i18n.T("❌ трава '%s' не найдена\n"), query)
+	case 1:
+		sh.current = &matches[0]
+	default:
+		fmt.Printf(i18n.T("Под '%s' подходит несколько трав, укажите ID:\n"), query)
+		for _, herb := range matches {
+			fmt.Printf("  ID %-4d %s\n", herb.ID, describeHerb(&herb))
+		}
+	}
+}
+
+// withCurrentHerb substitutes the current herb ID for @ and adds it to
+// commands that need an ID when none was given
+func (sh *shell) withCurrentHerb(words []string) []string {
+	if sh.current == nil {
+		return words
+	}
+
+	id := strconv.Itoa(sh.current.ID)
+	for i, word := range words {
+		if word == "@" {
+			words[i] = id
+		}
+	}
+
+	found, rest, err := rootCmd.Find(words)
+	if err != nil || !takesCurrentHerb(found) {
+		return words
+	}
+	if err := found.ParseFlags(rest); err != nil || len(found.Flags().Args()) > 0 {
+		return words
+	}
+	return append(words, id)
+}
+
+// takesCurrentHerb reports whether c accepts a herb ID that may default to the current herb
+func takesCurrentHerb(c *cobra.Command) bool {
+	for _, candidate := range []*cobra.Command{getHerbCmd, updateHerbCmd, deleteHerbCmd, editHerbCmd} {
+		if c == candidate {
+			return true
+		}
+	}
+	return false
+}
+
+// refreshCurrent reloads the current herb after a command, dropping it if it was deleted
+func (sh *shell) refreshCurrent() {
+	if sh.current == nil {
+		return
+	}
+	herb, err := repository.NewHerbRepository(database.GetDB()).GetByID(sh.current.ID)
+	if err != nil {
+		sh.current = nil
+		return
+	}
+	sh.current = herb
+}
+
+// refreshHerbNames reloads the herb names used for tab completion
+func (sh *shell) refreshHerbNames() {
+	herbs, err := repository.NewHerbRepository(database.GetDB()).GetAll()
+	if err != nil {
+		return
+	}
+
+	sh.herbNames = sh.herbNames[:0]
+	for _, herb := range herbs {
+		sh.herbNames = append(sh.herbNames, herb.Name)
+		if herb.LatinName != "" {
+			sh.herbNames = append(sh.herbNames, herb.LatinName)
+		}
+	}
+}
+
+// complete implements tab completion: command names at the start of the line
+// and herb names in the argument that follows a command
+func (sh *shell) complete(line string, pos int, key rune) (string, int, bool) {
+	if key != '\t' {
+		return "", 0, false
+	}
+
+	before := line[:pos]
+	words := strings.Fields(before)
+	finished := len(words)
+	if last, _ := lastRune(before); finished > 0 && !unicode.IsSpace(last) {
+		finished-- // the last word is still being typed
+	}
+
+	var (
+		start      int
+		candidates []string
+	)
+	if finished == 0 || (finished == 1 && words[0] == "herb") {
+		start = strings.LastIndexFunc(before, unicode.IsSpace) + 1
+		candidates = shellCommandNames(finished == 0)
+	} else {
+		// Herb names may contain spaces, so the whole argument after the command is completed
+		commandWords := 1
+		if words[0] == "herb" {
+			commandWords = 2
+		}
+		if !completesHerbName(words[commandWords-1]) {
+			return "", 0, false
+		}
+		start = skipWords(before, commandWords)
+		if strings.HasPrefix(before[start:], "-") {
+			return "", 0, false
+		}
+		for _, name := range sh.herbNames {
+			candidates = append(candidates, quoteIfNeeded(name))
+		}
+	}
+
+	prefix := strings.ToLower(strings.TrimLeft(before[start:], `"`))
+	var matches []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(strings.ToLower(strings.Trim(candidate, `"`)), prefix) {
+			matches = append(matches, candidate)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", 0, false
+	case 1:
+		completed := line[:start] + matches[0] + " "
+		return completed + line[pos:], len(completed), true
+	default:
+		fmt.Fprintf(sh.terminal, "%s\n", strings.Join(matches, "  "))
+
+		unquoted := make([]string, len(matches))
+		quoted := false
+		for i, match := range matches {
+			unquoted[i] = strings.Trim(match, `"`)
+			quoted = quoted || unquoted[i] != match
+		}
+		common := commonPrefix(unquoted)
+		if quoted {
+			common = `"` + common
+		}
+		if len(common) <= len(before[start:]) {
+			return line, pos, true
+		}
+		completed := line[:start] + common
+		return completed + line[pos:], len(completed), true
+	}
+}
+
+// completesHerbName reports whether the argument of a command is a herb name
+func completesHerbName(command string) bool {
+	return command == "search" || command == "use"
+}
+
+// skipWords returns the offset in s just after its first n words and the following spaces
+func skipWords(s string, n int) int {
+	i := 0
+	for word := 0; word <= n; word++ {
+		for i < len(s) && s[i] == ' ' {
+			i++
+		}
+		if word == n {
+			break
+		}
+		for i < len(s) && s[i] != ' ' {
+			i++
+		}
+	}
+	return i
+}
+
+// shellCommandNames lists the command names available at the start of a line
+func shellCommandNames(withBuiltins bool) []string {
+	var names []string
+	for _, c := range herbCmd.Commands() {
+		if c.IsAvailableCommand() {
+			names = append(names, c.Name())
+		}
+	}
+	if withBuiltins {
+		names = append(names, "herb", "use", "unuse", "help", "exit")
+		for _, c := range rootCmd.Commands() {
+			if c.IsAvailableCommand() && c != herbCmd && c.Name() != "shell" {
+				names = append(names, c.Name())
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// commonPrefix returns the longest common prefix of words
+func commonPrefix(words []string) string {
+	prefix := words[0]
+	for _, word := range words[1:] {
+		for !strings.HasPrefix(word, prefix) {
+			_, size := lastRune(prefix)
+			prefix = prefix[:len(prefix)-size]
+		}
+	}
+	return prefix
+}
+
+// lastRune returns the last rune of s and its size in bytes
+func lastRune(s string) (rune, int) {
+	runes := []rune(s)
+	if len(runes) == 0 {
+		return 0, 0
+	}
+	r := runes[len(runes)-1]
+	return r, len(string(r))
+}
+
+// quoteIfNeeded puts s in double quotes if it contains spaces
+func quoteIfNeeded(s string) string {
+	if strings.ContainsFunc(s, unicode.IsSpace) {
+		return `"` + s + `"`
+	}
+	return s
+}
+
+// splitShellLine splits a line into words, honouring single and double quotes
+// and backslash escapes
+func splitShellLine(line string) ([]string, error) {
+	var (
+		words   []string
+		current strings.Builder
+		inWord  bool
+		quote   rune
+		escaped bool
+	)
+
+	for _, r := range line {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped, inWord = true, true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote, inWord = r, true
+		case unicode.IsSpace(r):
+			if inWord {
+				words = append(words, current.String())
+				current.Reset()
+				inWord = false
+			}
+		default:
+			current.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, i18n.Errorf("незакрытая кавычка")
+	}
+	if inWord {
+		words = append(words, current.String())
+	}
+	return words, nil
+}
+
+// resetFlags restores the default values of all command flags, since cobra
+// keeps the values parsed by a previous run of the same command tree.
+// The global flags of the root command are restored with restoreFlags instead.
+func resetFlags(c *cobra.Command) {
+	reset := func(f *pflag.Flag) {
+		if slice, ok := f.Value.(pflag.SliceValue); ok {
+			slice.Replace(nil)
+		} else {
+			f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	}
+	c.LocalNonPersistentFlags().VisitAll(reset)
+	if c.HasParent() {
+		c.PersistentFlags().VisitAll(reset)
+	}
+	for _, child := range c.Commands() {
+		resetFlags(child)
+	}
+}
+
+// flagState is the value of a flag and whether it was set
+type flagState struct {
+	value   string
+	slice   []string
+	changed bool
+}
+
+// saveFlags records the current values of the flags
+func saveFlags(flags *pflag.FlagSet) map[string]flagState {
+	states := make(map[string]flagState)
+	flags.VisitAll(func(f *pflag.Flag) {
+		state := flagState{value: f.Value.String(), changed: f.Changed}
+		if slice, ok := f.Value.(pflag.SliceValue); ok {
+			state.slice = append([]string(nil), slice.GetSlice()...)
+		}
+		states[f.Name] = state
+	})
+	return states
+}
+
+// restoreFlags sets the flags back to the values recorded by saveFlags, so that
+// a global flag given on one shell line, e.g. --lang en, does not carry over
+// to the next one
+func restoreFlags(flags *pflag.FlagSet, states map[string]flagState) {
+	flags.VisitAll(func(f *pflag.Flag) {
+		state, ok := states[f.Name]
+		if !ok {
+			return
+		}
+		if slice, isSlice := f.Value.(pflag.SliceValue); isSlice {
+			slice.Replace(append([]string(nil), state.slice...))
+		} else {
+			f.Value.Set(state.value)
+		}
+		f.Changed = state.changed
+	})
+}
+
+// shellHistory keeps the shell history and persists it between sessions.
+// It implements term.History.
+type shellHistory struct {
+	lines []string // oldest first
+	path  string
+}
+
+// loadShellHistory reads the history file from the home directory, if there is one
+func loadShellHistory() *shellHistory {
+	h := &shellHistory{}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return h
+	}
+	h.path = filepath.Join(home, ".herbs-cli_history")
+
+	file, err := os.Open(h.path)
+	if err != nil {
+		return h
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			h.lines = append(h.lines, line)
+		}
+	}
+	h.trim()
+	return h
+}
+
+// Add appends a line to the history, skipping immediate repeats
+func (h *shellHistory) Add(entry string) {
+	if entry == "" || (len(h.lines) > 0 && h.lines[len(h.lines)-1] == entry) {
+		return
+	}
+	h.lines = append(h.lines, entry)
+	h.trim()
+}
+
+// Len returns the number of lines in the history
+func (h *shellHistory) Len() int {
+	return len(h.lines)
+}
+
+// At returns a line of history, where 0 is the most recent one
+func (h *shellHistory) At(idx int) string {
+	return h.lines[len(h.lines)-1-idx]
+}
+
+func (h *shellHistory) trim() {
+	if len(h.lines) > shellHistoryLimit {
+		h.lines = h.lines[len(h.lines)-shellHistoryLimit:]
+	}
+}
+
+// save writes the history back to the history file
+func (h *shellHistory) save() {
+	if h.path == "" {
+		return
+	}
+	data := strings.Join(h.lines, "\n") + "\n"
+	if err := os.WriteFile(h.path, []byte(data), 0o600); err != nil {
+		fmt.Fprintf(os.Stderr, i18n.T("Не удалось сохранить историю команд: %v\n"), err)
+	}
+}
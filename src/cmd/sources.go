@@ -0,0 +1,316 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gloowl/simple_crud/src/internal/bibliography"
+	"github.com/gloowl/simple_crud/src/internal/database"
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+	"github.com/gloowl/simple_crud/src/internal/models"
+	"github.com/gloowl/simple_crud/src/internal/repository"
+
+	"github.com/spf13/cobra"
+)
+
+// sourceCmd groups the commands for bibliographic sources
+var sourceCmd = &cobra.Command{
+	Use:   "source",
+	Short: "Источники сведений о травах",
+	Long: `Книги, статьи и сайты, на которые ссылаются сведения о травах.
+
+Ссылки на источники добавляются командой herb cite для описания травы,
+ее токсичности или отдельного способа применения и выводятся в herb get --sources.`,
+}
+
+// addSourceCmd adds a source
+var addSourceCmd = &cobra.Command{
+	Use:   "add TITLE",
+	Short: "Добавить источник",
+	Long: `Добавляет источник. Авторы указываются по одному в --author в виде
+"Фамилия, Имя"; название организации указывается без запятой.`,
+	Args: cobra.ExactArgs(1),
+	Example: `  herbs-cli source add "Лекарственные растения" --author "Иванов, И. И." --year 2015 --publisher Медицина --isbn 978-5-225-10000-1
+  herbs-cli source add "Hypericum perforatum and drug interactions" --kind article --author "Smith, J." --journal "J Ethnopharmacol" --volume 12 --pages 100-110 --doi 10.1016/j.jep.2019.112345`,
+	RunE: addSource,
+}
+
+// listSourcesCmd lists all sources
+var listSourcesCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Показать все источники",
+	RunE:  listSources,
+}
+
+// deleteSourceCmd removes a source
+var deleteSourceCmd = &cobra.Command{
+	Use:   "delete ID",
+	Short: "Удалить источник",
+	Long:  `Удаляет источник вместе со всеми ссылками на него.`,
+	Args:  cobra.ExactArgs(1),
+	RunE:  deleteSource,
+}
+
+// exportSourcesCmd exports sources for reference managers
+var exportSourcesCmd = &cobra.Command{
+	Use:   "export [HERB_ID...]",
+	Short: "Экспортировать источники в BibTeX или CSL-JSON",
+	Long: `Выводит источники, на которые ссылаются сведения об указанных травах,
+или, без аргументов, все источники в формате BibTeX или CSL-JSON.`,
+	Example: `  herbs-cli source export 1 5 > herbs.bib
+  herbs-cli source export --to csl-json > herbs.json`,
+	RunE: exportSources,
+}
+
+// citeHerbCmd cites a source for a fact about a herb
+var citeHerbCmd = &cobra.Command{
+	Use:   "cite HERB_ID SOURCE_ID",
+	Short: "Сослаться на источник сведений о траве",
+	Long: `Указывает источник сведений о траве.
+
+Сведения (--fact):
+  herb      - описание травы (по умолчанию)
+  toxicity  - токсичность
+  usage     - способ применения с ID из --usage`,
+	Args: cobra.ExactArgs(2),
+	Example: `  herbs-cli herb cite 1 3 --pages 45-47
+  herbs-cli herb cite 1 3 --fact toxicity --pages 48
+  herbs-cli herb cite 1 4 --usage 12`,
+	RunE: citeHerb,
+}
+
+// unciteHerbCmd removes a citation
+var unciteHerbCmd = &cobra.Command{
+	Use:   "uncite CITATION_ID",
+	Short: "Удалить ссылку на источник",
+	Args:  cobra.ExactArgs(1),
+	RunE:  unciteHerb,
+}
+
+func init() {
+	rootCmd.AddCommand(sourceCmd)
+	sourceCmd.AddCommand(addSourceCmd)
+	sourceCmd.AddCommand(listSourcesCmd)
+	sourceCmd.AddCommand(deleteSourceCmd)
+	sourceCmd.AddCommand(exportSourcesCmd)
+
+	herbCmd.AddCommand(citeHerbCmd)
+	herbCmd.AddCommand(unciteHerbCmd)
+
+	addSourceCmd.Flags().String("kind", models.SourceBook, "вид источника: book, article, website или other")
+	addSourceCmd.Flags().StringArray("author", nil, "автор в виде \"Фамилия, Имя\"; флаг можно повторять")
+	addSourceCmd.Flags().Int("year", 0, "год издания")
+	addSourceCmd.Flags().String("journal", "", "журнал статьи или название сайта")
+	addSourceCmd.Flags().String("publisher", "", "издательство")
+	addSourceCmd.Flags().String("volume", "", "том")
+	addSourceCmd.Flags().String("issue", "", "номер выпуска")
+	addSourceCmd.Flags().String("pages", "", "страницы статьи, например 100-110")
+	addSourceCmd.Flags().String("doi", "", "DOI")
+	addSourceCmd.Flags().String("isbn", "", "ISBN")
+	addSourceCmd.Flags().String("url", "", "адрес в интернете")
+
+	exportSourcesCmd.Flags().String("to", "bibtex", "формат: bibtex или csl-json")
+
+	citeHerbCmd.Flags().String("fact", "", "сведения: herb, toxicity или usage")
+	citeHerbCmd.Flags().Int("usage", 0, "ID способа применения")
+	citeHerbCmd.Flags().String("pages", "", "страницы источника, например 45-47")
+}
+
+// printHerbCitations prints the sources cited for a herb, grouped by fact
+func printHerbCitations(citations []models.Citation) {
+	if len(citations) == 0 {
+		fmt.Println(i18n.T("Источники не указаны."))
+		return
+	}
+
+	fmt.Println(i18n.T("Источники:"))
+	for _, citation := range citations {
+		reference := citation.Source.Reference()
+		if citation.Pages != "" {
+			reference += fmt.Sprintf(i18n.T(", с. %s"), citation.Pages)
+		}
+		fmt.Printf("  [%d] %s: %s\n", citation.ID, citation.FactLabel(), reference)
+	}
+}
+
+func addSource(cmd *cobra.Command, args []string) error {
+	db := database.GetDB()
+	if db == nil {
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+	}
+
+	flags := cmd.Flags()
+	source := &models.Source{Title: args[0]}
+	source.Kind, _ = flags.GetString("kind")
+	source.Authors, _ = flags.GetStringArray("author")
+	source.Year, _ = flags.GetInt("year")
+	source.Container, _ = flags.GetString("journal")
+	source.Publisher, _ = flags.GetString("publisher")
+	source.Volume, _ = flags.GetString("volume")
+	source.Issue, _ = flags.GetString("issue")
+	source.Pages, _ = flags.GetString("pages")
+	source.DOI, _ = flags.GetString("doi")
+	source.ISBN, _ = flags.GetString("isbn")
+	source.URL, _ = flags.GetString("url")
+
+	if err := repository.NewSourceRepository(db).Create(source); err != nil {
+		return i18n.Errorf("не удалось добавить источник: %w", err)
+	}
+
+	fmt.Printf(i18n.T("✅ Источник добавлен с ID: %d\n"), source.ID)
+	fmt.Println("  " + source.Reference())
+	return nil
+}
+
+func listSources(cmd *cobra.Command, args []string) error {
+	db := database.GetDB()
+	if db == nil {
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+	}
+
+	sources, err := repository.NewSourceRepository(db).GetAll()
+	if err != nil {
+		return err
+	}
+
+	if len(sources) == 0 {
+		fmt.Println(i18n.T("Источники не найдены"))
+		return nil
+	}
+
+	for _, source := range sources {
+		fmt.Printf("[%d] %s (%s)\n", source.ID, source.Reference(), source.KindLabel())
+	}
+	return nil
+}
+
+func deleteSource(cmd *cobra.Command, args []string) error {
+	db := database.GetDB()
+	if db == nil {
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+	}
+
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return i18n.Errorf("неверный ID: %s", args[0])
+	}
+
+	if err := repository.NewSourceRepository(db).Delete(id); err != nil {
+		return err
+	}
+
+	fmt.Printf(i18n.T("✅ Источник с ID %d удален\n"), id)
+	return nil
+}
+
+func exportSources(cmd *cobra.Command, args []string) error {
+	db := database.GetDB()
+	if db == nil {
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+	}
+	repo := repository.NewSourceRepository(db)
+
+	format, _ := cmd.Flags().GetString("to")
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format != "bibtex" && format != "csl-json" {
+		return i18n.Errorf("неизвестный формат: %s (доступно: bibtex, csl-json)", format)
+	}
+
+	var (
+		sources []models.Source
+		err     error
+	)
+	if len(args) == 0 {
+		sources, err = repo.GetAll()
+	} else {
+		ids := make([]int, len(args))
+		for i, arg := range args {
+			if ids[i], err = strconv.Atoi(arg); err != nil {
+				return i18n.Errorf("неверный ID: %s", arg)
+			}
+		}
+		sources, err = repo.GetByHerbs(ids)
+	}
+	if err != nil {
+		return err
+	}
+
+	if format == "csl-json" {
+		return bibliography.WriteCSLJSON(os.Stdout, sources)
+	}
+	return bibliography.WriteBibTeX(os.Stdout, sources)
+}
+
+func citeHerb(cmd *cobra.Command, args []string) error {
+	db := database.GetDB()
+	if db == nil {
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+	}
+	repos := repository.NewRepositories(db)
+
+	herbID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return i18n.Errorf("неверный ID: %s", args[0])
+	}
+	sourceID, err := strconv.Atoi(args[1])
+	if err != nil {
+		return i18n.Errorf("неверный ID: %s", args[1])
+	}
+
+	herb, err := repos.Herbs.GetByID(herbID)
+	if err != nil {
+		return err
+	}
+	source, err := repos.Sources.GetByID(sourceID)
+	if err != nil {
+		return err
+	}
+
+	fact, _ := cmd.Flags().GetString("fact")
+	pages, _ := cmd.Flags().GetString("pages")
+	citation := &models.Citation{
+		SourceID: source.ID,
+		HerbID:   herb.ID,
+		Fact:     strings.ToLower(strings.TrimSpace(fact)),
+		Pages:    strings.TrimSpace(pages),
+	}
+	if cmd.Flags().Changed("usage") {
+		usageID, _ := cmd.Flags().GetInt("usage")
+		citation.UsageID = &usageID
+		if citation.Fact == "" {
+			citation.Fact = models.FactUsage
+		}
+	}
+	if citation.Fact == "" {
+		citation.Fact = models.FactHerb
+	}
+
+	if err := repos.Citations.Create(citation); err != nil {
+		return i18n.Errorf("не удалось добавить ссылку на источник: %w", err)
+	}
+
+	fmt.Printf(i18n.T("✅ Для травы «%s» (%s) указан источник «%s», ID ссылки: %d\n"),
+		herb.Name, citation.FactLabel(), source.Title, citation.ID)
+	return nil
+}
+
+func unciteHerb(cmd *cobra.Command, args []string) error {
+	db := database.GetDB()
+	if db == nil {
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+	}
+
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return i18n.Errorf("неверный ID: %s", args[0])
+	}
+
+	if err := repository.NewCitationRepository(db).Delete(id); err != nil {
+		return err
+	}
+
+	fmt.Printf(i18n.T("✅ Ссылка на источник с ID %d удалена\n"), id)
+	return nil
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gloowl/simple_crud/src/internal/database"
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+	"github.com/gloowl/simple_crud/src/internal/models"
+	"github.com/gloowl/simple_crud/src/internal/repository"
+
+	"github.com/spf13/cobra"
+)
+
+// taxonomyCmd groups the commands that work with the taxonomy
+var taxonomyCmd = &cobra.Command{
+	Use:   "taxonomy",
+	Short: "Работа с таксономией трав",
+	Long: `Команды для работы с иерархией таксонов: семейство → род → вид.
+
+Роды и виды создаются автоматически по латинским названиям трав;
+семейства добавляются командой taxonomy set.`,
+}
+
+// taxonomyTreeCmd prints the taxonomy hierarchy
+var taxonomyTreeCmd = &cobra.Command{
+	Use:   "tree",
+	Short: "Показать дерево таксонов",
+	Long: `Выводит иерархию семейств, родов и видов с количеством трав
+в каждом таксоне (включая травы вложенных таксонов).`,
+	RunE: printTaxonomyTree,
+}
+
+// taxonomySetCmd creates a taxon or moves it under another parent
+var taxonomySetCmd = &cobra.Command{
+	Use:   "set RANK NAME",
+	Short: "Добавить таксон или изменить его родителя",
+	Long: `Создает таксон ранга RANK (family, genus или species) с названием NAME.
+Если такой таксон уже есть, он переносится к родителю из --parent.
+
+Родителем рода может быть только семейство, родителем вида - только род.
+Вид переносится только к роду из его биномиального названия; чтобы
+перенести его к другому роду, укажите --force.`,
+	Args: cobra.ExactArgs(2),
+	Example: `  herbs-cli taxonomy set family Solanaceae
+  herbs-cli taxonomy set genus Atropa --parent Solanaceae`,
+	RunE: setTaxon,
+}
+
+func init() {
+	rootCmd.AddCommand(taxonomyCmd)
+	taxonomyCmd.AddCommand(taxonomyTreeCmd)
+	taxonomyCmd.AddCommand(taxonomySetCmd)
+
+	taxonomySetCmd.Flags().String("parent", "", "название родительского таксона")
+	taxonomySetCmd.Flags().Bool("force", false, "перенести вид к роду, не совпадающему с его названием")
+}
+
+// taxonLink describes a taxon for buildTree
+func taxonLink(taxon models.Taxon) (int, *int, int) {
+	return taxon.ID, taxon.ParentID, taxon.HerbCount
+}
+
+// taxonLine formats a taxon of the tree with the herbs of its whole subtree
+func taxonLine(node *treeNode[models.Taxon]) string {
+	return fmt.Sprintf(i18n.T("%s (%s) — трав: %d"), node.item.Name, models.RankLabel(node.item.Rank), node.total)
+}
+
+func printTaxonomyTree(cmd *cobra.Command, args []string) error {
+	db := database.GetDB()
+	if db == nil {
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+	}
+	repos := repository.NewRepositories(db)
+
+	taxa, err := repos.Taxa.GetAll()
+	if err != nil {
+		return i18n.Errorf("не удалось получить таксоны: %v", err)
+	}
+	herbs, err := repos.Herbs.GetAll()
+	if err != nil {
+		return i18n.Errorf("не удалось получить список трав: %v", err)
+	}
+
+	if len(taxa) == 0 {
+		fmt.Println(i18n.T("Таксоны не найдены. Укажите латинские названия трав или добавьте таксоны командой 'taxonomy set'."))
+	}
+
+	writeTree(buildTree(taxa, taxonLink), taxonLine)
+
+	unlinked := 0
+	for _, herb := range herbs {
+		if herb.TaxonID == nil {
+			unlinked++
+		}
+	}
+	if unlinked > 0 {
+		fmt.Printf(i18n.T("\nТрав без таксона: %d\n"), unlinked)
+	}
+
+	return nil
+}
+
+func setTaxon(cmd *cobra.Command, args []string) error {
+	db := database.GetDB()
+	if db == nil {
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+	}
+	taxonRepo := repository.NewTaxonRepository(db)
+
+	rank, err := models.ParseRank(args[0])
+	if err != nil {
+		return err
+	}
+	taxon := &models.Taxon{Rank: rank, Name: strings.Join(strings.Fields(args[1]), " ")}
+
+	if parentName, _ := cmd.Flags().GetString("parent"); strings.TrimSpace(parentName) != "" {
+		parentRank := models.ParentRank(rank)
+		if parentRank == "" {
+			return i18n.Errorf("у таксона ранга %s не может быть родителя", models.RankLabel(rank))
+		}
+		parent, err := taxonRepo.GetByName(parentRank, strings.TrimSpace(parentName))
+		if err != nil {
+			return err
+		}
+		force, _ := cmd.Flags().GetBool("force")
+		genus := models.SpeciesGenus(taxon.Name)
+		if rank == models.RankSpecies && !force && !strings.EqualFold(genus, parent.Name) {
+			return i18n.Errorf("вид «%s» относится к роду «%s», а не «%s»; укажите --force, чтобы все равно перенести его", taxon.Name, genus, parent.Name)
+		}
+		taxon.ParentID = &parent.ID
+	}
+
+	if err := taxonRepo.Save(taxon); err != nil {
+		return i18n.Errorf("не удалось сохранить таксон: %w", err)
+	}
+
+	fmt.Printf(i18n.T("✅ Таксон «%s» (%s) сохранен с ID: %d\n"), taxon.Name, models.RankLabel(taxon.Rank), taxon.ID)
+	return nil
+}
@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+
+	"github.com/gloowl/simple_crud/src/internal/database"
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+	"github.com/gloowl/simple_crud/src/internal/models"
+	"github.com/gloowl/simple_crud/src/internal/repository"
+	"github.com/gloowl/simple_crud/src/internal/table"
+
+	"github.com/spf13/viper"
+)
+
+// templateExt is the extension of named template files
+const templateExt = ".tmpl"
+
+// templateFuncs are the helper functions available in --format templates
+var templateFuncs = template.FuncMap{
+	"truncate": func(width int, s string) string { return table.Truncate(s, width) },
+	"upper":    strings.ToUpper,
+	"lower":    strings.ToLower,
+	"date": func(layout string, t time.Time) string {
+		return t.In(models.DisplayLocation()).Format(layout)
+	},
+	"join":  joinValues,
+	"pluck": pluckField,
+}
+
+// templatesDir returns the directory with named templates: the templates_dir
+// setting, or herbs-cli/templates in the user config directory
+func templatesDir() (string, error) {
+	if dir := viper.GetString("templates_dir"); dir != "" {
+		return dir, nil
+	}
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", i18n.Errorf("не удалось определить каталог конфигурации: %v", err)
+	}
+	return filepath.Join(configDir, "herbs-cli", "templates"), nil
+}
+
+// parseFormat parses the value of --format. A value without "{{" is the name
+// of a template file in the templates directory; all files from that directory
+// are loaded, so named templates can include each other.
+func parseFormat(format string) (*template.Template, error) {
+	if strings.Contains(format, "{{") {
+		tmpl, err := template.New("format").Funcs(templateFuncs).Parse(format)
+		if err != nil {
+			return nil, i18n.Errorf("ошибка в шаблоне --format: %v", err)
+		}
+		return tmpl, nil
+	}
+
+	dir, err := templatesDir()
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimSuffix(format, templateExt) + templateExt
+	if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+		return nil, i18n.Errorf("шаблон %s не найден в каталоге %s", format, dir)
+	}
+
+	tmpl, err := template.New(name).Funcs(templateFuncs).ParseGlob(filepath.Join(dir, "*"+templateExt))
+	if err != nil {
+		return nil, i18n.Errorf("ошибка в шаблоне %s: %v", format, err)
+	}
+	return tmpl.Lookup(name), nil
+}
+
+// templateHerb is the data of --format templates: the herb fields, the herb
+// itself as .Herb, and its regions, usages and alternative names. These are
+// loaded only for templates that refer to them.
+type templateHerb struct {
+	models.Herb
+	Regions []models.Region
+	Usages  []models.Usage
+	Names   []models.HerbName
+}
+
+// writeHerbsTemplate executes tmpl once for every herb
+func writeHerbsTemplate(w io.Writer, tmpl *template.Template, herbs []models.Herb) error {
+	var repos *repository.Repositories
+	if usesDetails(tmpl) {
+		db := database.GetDB()
+		if db == nil {
+			return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+		}
+		repos = repository.NewRepositories(db)
+	}
+
+	var buf bytes.Buffer
+	for i := range herbs {
+		data := templateHerb{Herb: herbs[i]}
+		if repos != nil {
+			details, err := repos.HerbDetails(herbs[i].ID)
+			if err != nil {
+				return err
+			}
+			data.Regions, data.Usages, data.Names = details.Regions, details.Usages, details.Names
+		}
+
+		buf.Reset()
+		if err := tmpl.Execute(&buf, &data); err != nil {
+			return i18n.Errorf("ошибка выполнения шаблона: %v", err)
+		}
+		if !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+			buf.WriteByte('\n')
+		}
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// detailFields are the fields of templateHerb that are loaded on demand
+var detailFields = map[string]bool{"Regions": true, "Usages": true, "Names": true}
+
+// usesDetails reports whether any template associated with tmpl refers to a
+// field that is loaded on demand
+func usesDetails(tmpl *template.Template) bool {
+	for _, t := range tmpl.Templates() {
+		if t.Tree != nil && nodeUsesDetails(t.Tree.Root) {
+			return true
+		}
+	}
+	return false
+}
+
+// nodeUsesDetails reports whether node may refer to a detail field. Nodes it
+// cannot decide about count as using the details, which are then loaded anyway.
+func nodeUsesDetails(node parse.Node) bool {
+	switch n := node.(type) {
+	case *parse.FieldNode:
+		return detailFields[n.Ident[0]]
+	case *parse.VariableNode:
+		// $.Regions or $h.Usages: the first identifier is the variable itself
+		for _, ident := range n.Ident[1:] {
+			if detailFields[ident] {
+				return true
+			}
+		}
+		return false
+	case *parse.ChainNode:
+		// (index . 0).Usages
+		for _, field := range n.Field {
+			if detailFields[field] {
+				return true
+			}
+		}
+		return nodeUsesDetails(n.Node)
+	case *parse.ListNode:
+		if n == nil {
+			return false
+		}
+		for _, child := range n.Nodes {
+			if nodeUsesDetails(child) {
+				return true
+			}
+		}
+		return false
+	case *parse.ActionNode:
+		return nodeUsesDetails(n.Pipe)
+	case *parse.PipeNode:
+		if n == nil {
+			return false
+		}
+		for _, cmd := range n.Cmds {
+			if nodeUsesDetails(cmd) {
+				return true
+			}
+		}
+		return false
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			if nodeUsesDetails(arg) {
+				return true
+			}
+		}
+		return false
+	case *parse.IfNode:
+		return nodeUsesDetails(n.Pipe) || nodeUsesDetails(n.List) || nodeUsesDetails(n.ElseList)
+	case *parse.RangeNode:
+		return nodeUsesDetails(n.Pipe) || nodeUsesDetails(n.List) || nodeUsesDetails(n.ElseList)
+	case *parse.WithNode:
+		return nodeUsesDetails(n.Pipe) || nodeUsesDetails(n.List) || nodeUsesDetails(n.ElseList)
+	case *parse.TemplateNode:
+		return nodeUsesDetails(n.Pipe)
+	case *parse.TextNode, *parse.DotNode, *parse.NilNode, *parse.BoolNode, *parse.NumberNode,
+		*parse.StringNode, *parse.IdentifierNode, *parse.BreakNode, *parse.ContinueNode, *parse.CommentNode:
+		return false
+	}
+	return true
+}
+
+// joinValues joins the elements of a slice with sep
+func joinValues(sep string, items any) (string, error) {
+	v := reflect.ValueOf(items)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return "", i18n.Errorf("join: ожидается список, получено %T", items)
+	}
+	parts := make([]string, v.Len())
+	for i := range parts {
+		parts[i] = fmt.Sprint(v.Index(i).Interface())
+	}
+	return strings.Join(parts, sep), nil
+}
+
+// pluckField returns the named field of every element of a slice of structs,
+// e.g. pluck "Name" .Regions
+func pluckField(field string, items any) ([]any, error) {
+	v := reflect.ValueOf(items)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, i18n.Errorf("pluck: ожидается список, получено %T", items)
+	}
+	values := make([]any, v.Len())
+	for i := range values {
+		item := reflect.Indirect(v.Index(i))
+		if item.Kind() != reflect.Struct {
+			return nil, i18n.Errorf("pluck: элемент %s не является структурой", item.Type())
+		}
+		f := item.FieldByName(field)
+		if !f.IsValid() {
+			return nil, i18n.Errorf("pluck: у %s нет поля %s", item.Type(), field)
+		}
+		values[i] = f.Interface()
+	}
+	return values, nil
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"text/template"
+
+	"github.com/gloowl/simple_crud/src/internal/models"
+)
+
+func TestUsesDetails(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		want   bool
+	}{
+		{"herb fields", `{{.Name}} ({{.LatinName}})`, false},
+		{"herb itself", `{{.Herb.Name | upper}}`, false},
+		{"field", `{{.Regions}}`, true},
+		{"field in pipeline", `{{join ", " (pluck "Name" .Usages)}}`, true},
+		{"root variable", `{{range .Herb.Name}}{{$.Regions}}{{end}}`, true},
+		{"named variable", `{{$h := .}}{{$h.Usages}}`, true},
+		{"variable without details", `{{$h := .}}{{$h.Name}}`, false},
+		{"chain", `{{(index . 0).Usages}}`, true},
+		{"alternative names", `{{range .Names}}{{.Name}}{{end}}`, true},
+		{"if condition", `{{if .Regions}}yes{{end}}`, true},
+		{"else branch", `{{if .IsPoisonous}}yes{{else}}{{.Usages}}{{end}}`, true},
+		{"range body", `{{range .Name}}{{.Regions}}{{end}}`, true},
+		{"with else branch", `{{with .Description}}{{.}}{{else}}{{.Regions}}{{end}}`, true},
+		{"literals and comments", `{{/* .Regions */}}{{"Regions"}} {{1}} {{true}} {{nil | print}}`, false},
+		{"template call", `{{define "r"}}{{.Regions}}{{end}}{{template "r" .}}`, true},
+		{"template call without details", `{{define "n"}}{{.Name}}{{end}}{{template "n" .}}`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := template.New("format").Funcs(templateFuncs).Parse(tt.format)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.format, err)
+			}
+			if got := usesDetails(tmpl); got != tt.want {
+				t.Errorf("usesDetails(%q) = %v, want %v", tt.format, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNodeUsesDetailsNil(t *testing.T) {
+	if nodeUsesDetails(nil) != true {
+		t.Error("nodeUsesDetails(nil) = false, want true for an unknown node")
+	}
+}
+
+func TestWriteHerbsTemplateWithoutDetails(t *testing.T) {
+	tmpl, err := template.New("format").Funcs(templateFuncs).Parse(`{{.ID}} {{.Name}} {{.Herb.LatinName}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	herbs := []models.Herb{{ID: 1, Name: "Мята", LatinName: "Mentha piperita"}, {ID: 2, Name: "Шалфей"}}
+	if err := writeHerbsTemplate(&buf, tmpl, herbs); err != nil {
+		t.Fatalf("writeHerbsTemplate() error: %v", err)
+	}
+	if want := "1 Мята Mentha piperita\n2 Шалфей \n"; buf.String() != want {
+		t.Errorf("writeHerbsTemplate() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestJoinValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		sep     string
+		items   any
+		want    string
+		wantErr bool
+	}{
+		{"strings", ", ", []string{"a", "b", "c"}, "a, b, c", false},
+		{"values", "-", []any{1, "x", true}, "1-x-true", false},
+		{"array", "/", [2]int{3, 4}, "3/4", false},
+		{"empty", ", ", []string{}, "", false},
+		{"not a list", ", ", "abc", "", true},
+		{"nil", ", ", nil, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := joinValues(tt.sep, tt.items)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("joinValues() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("joinValues() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPluckField(t *testing.T) {
+	regions := []models.Region{{ID: 1, Name: "Урал"}, {ID: 2, Name: "Алтай"}}
+
+	tests := []struct {
+		name    string
+		field   string
+		items   any
+		want    []any
+		wantErr bool
+	}{
+		{"structs", "Name", regions, []any{"Урал", "Алтай"}, false},
+		{"pointers", "ID", []*models.Region{&regions[0], &regions[1]}, []any{1, 2}, false},
+		{"empty", "Name", []models.Region{}, []any{}, false},
+		{"unknown field", "Title", regions, nil, true},
+		{"not structs", "Name", []string{"a"}, nil, true},
+		{"not a list", "Name", regions[0], nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := pluckField(tt.field, tt.items)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("pluckField() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("pluckField() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/gloowl/simple_crud/src/internal/models"
+
+	"github.com/spf13/cobra"
+)
+
+// addToxicityFlags registers the flags that describe the toxicity of a herb
+func addToxicityFlags(cmd *cobra.Command) {
+	cmd.Flags().String("toxicity", "", "степень токсичности: none, mild, moderate, severe или deadly")
+	cmd.Flags().StringSlice("toxic-parts", nil, "ядовитые части растения через запятую: whole, root, rhizome, bulb, stem, bark, leaf, flower, fruit, berry, seed, sap")
+	cmd.Flags().StringSlice("compounds", nil, "токсичные вещества через запятую")
+	cmd.Flags().StringSlice("symptoms", nil, "симптомы отравления через запятую")
+}
+
+// applyToxicityFlags copies the toxicity flags that were given to herb.
+// --toxicity takes precedence over the older --poisonous flag.
+func applyToxicityFlags(cmd *cobra.Command, herb *models.Herb) error {
+	flags := cmd.Flags()
+
+	if flags.Changed("poisonous") {
+		poisonous, _ := flags.GetBool("poisonous")
+		herb.SetPoisonous(poisonous)
+	}
+	if flags.Changed("toxicity") {
+		value, _ := flags.GetString("toxicity")
+		level, err := models.ParseToxicityLevel(value)
+		if err != nil {
+			return err
+		}
+		herb.SetToxicity(level)
+	}
+	if flags.Changed("toxic-parts") {
+		parts, _ := flags.GetStringSlice("toxic-parts")
+		herb.ToxicParts = cleanList(parts, true)
+	}
+	if flags.Changed("compounds") {
+		compounds, _ := flags.GetStringSlice("compounds")
+		herb.ToxicCompounds = cleanList(compounds, false)
+	}
+	if flags.Changed("symptoms") {
+		symptoms, _ := flags.GetStringSlice("symptoms")
+		herb.ToxicitySymptoms = cleanList(symptoms, false)
+	}
+
+	return nil
+}
+
+// cleanList trims the values, drops empty and repeated ones and optionally lowercases them
+func cleanList(values []string, lower bool) []string {
+	seen := make(map[string]bool, len(values))
+	cleaned := []string{}
+	for _, value := range values {
+		value = strings.Join(strings.Fields(value), " ")
+		if lower {
+			value = strings.ToLower(value)
+		}
+		if value == "" || seen[strings.ToLower(value)] {
+			continue
+		}
+		seen[strings.ToLower(value)] = true
+		cleaned = append(cleaned, value)
+	}
+	return cleaned
+}
+
+// mergeLists returns the values of a followed by those of b that a does not have
+func mergeLists(a, b []string) []string {
+	return cleanList(append(append([]string(nil), a...), b...), false)
+}
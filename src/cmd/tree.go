@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+)
+
+// treeNode is an item of a hierarchy (a region or a taxon) with its children
+type treeNode[T any] struct {
+	item     T
+	children []*treeNode[T]
+	total    int // herbs of the item and of all its descendants
+}
+
+// buildTree arranges items into trees and returns their roots. link returns the
+// ID of an item, the ID of its parent (nil for a root) and its own herb count.
+// Items whose parent is not among items become roots.
+func buildTree[T any](items []T, link func(T) (id int, parentID *int, herbs int)) []*treeNode[T] {
+	nodes := make(map[int]*treeNode[T], len(items))
+	for _, item := range items {
+		id, _, _ := link(item)
+		nodes[id] = &treeNode[T]{item: item}
+	}
+
+	var roots []*treeNode[T]
+	for _, item := range items {
+		id, parentID, _ := link(item)
+		node := nodes[id]
+		if parentID != nil {
+			if parent, ok := nodes[*parentID]; ok {
+				parent.children = append(parent.children, node)
+				continue
+			}
+		}
+		roots = append(roots, node)
+	}
+
+	var count func(node *treeNode[T]) int
+	count = func(node *treeNode[T]) int {
+		_, _, node.total = link(node.item)
+		for _, child := range node.children {
+			node.total += count(child)
+		}
+		return node.total
+	}
+	for _, root := range roots {
+		count(root)
+	}
+
+	return roots
+}
+
+// writeTree prints every tree under roots, one line per node formatted by line;
+// the descendants of a root are drawn with tree branches
+func writeTree[T any](roots []*treeNode[T], line func(*treeNode[T]) string) {
+	for _, root := range roots {
+		fmt.Println(line(root))
+		writeBranches(root.children, "", line)
+	}
+}
+
+// writeBranches prints nodes with tree branches; prefix is the indentation of the level
+func writeBranches[T any](nodes []*treeNode[T], prefix string, line func(*treeNode[T]) string) {
+	branch, last, pipe := "├── ", "└── ", "│   "
+	if i18n.Plain() {
+		branch, last, pipe = "|-- ", "`-- ", "|   "
+	}
+
+	for i, node := range nodes {
+		connector, indent := branch, pipe
+		if i == len(nodes)-1 {
+			connector, indent = last, "    "
+		}
+		fmt.Println(prefix + connector + line(node))
+		writeBranches(node.children, prefix+indent, line)
+	}
+}
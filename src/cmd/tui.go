@@ -0,0 +1,385 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gloowl/simple_crud/src/internal/database"
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+	"github.com/gloowl/simple_crud/src/internal/models"
+	"github.com/gloowl/simple_crud/src/internal/repository"
+	"github.com/gloowl/simple_crud/src/internal/table"
+	"github.com/gloowl/simple_crud/src/internal/tui"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// tuiCmd starts the full-screen catalog browser
+var tuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Полноэкранный просмотр каталога трав",
+	Long: `Открывает полноэкранный интерфейс со списком трав и подробной информацией
+о выбранной траве: регионами и способами применения.
+
+Клавиши:
+  ↑/↓, j/k, PgUp/PgDn  - перемещение по списку
+  /                    - поиск по названию (Enter - готово, Esc - сбросить)
+  p                    - показывать только ядовитые травы
+  e                    - редактировать траву в $EDITOR
+  d                    - удалить траву
+  r                    - обновить список
+  q, Ctrl+C            - выход`,
+	Args: cobra.NoArgs,
+	RunE: runTUI,
+}
+
+// tuiApp is the state of the full-screen catalog browser
+type tuiApp struct {
+	screen *tui.Screen
+	repos  *repository.Repositories
+
+	herbs    []models.Herb
+	selected int
+	offset   int
+
+	query         string
+	searching     bool
+	poisonousOnly bool
+	confirmDelete bool
+	status        string
+
+	details map[int]*models.HerbWithDetails
+}
+
+func init() {
+	rootCmd.AddCommand(tuiCmd)
+}
+
+func runTUI(cmd *cobra.Command, args []string) error {
+	db := database.GetDB()
+	if db == nil {
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+	}
+	if !isInteractive() || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return i18n.Errorf("для полноэкранного режима нужен терминал")
+	}
+
+	app := &tuiApp{repos: repository.NewRepositories(db)}
+	if err := app.reload(); err != nil {
+		return err
+	}
+
+	screen, err := tui.Open()
+	if err != nil {
+		return err
+	}
+	defer screen.Close()
+	app.screen = screen
+
+	for {
+		app.draw()
+
+		key, err := screen.ReadKey()
+		if err != nil {
+			return err
+		}
+		if quit := app.handleKey(key); quit {
+			return nil
+		}
+	}
+}
+
+// reload fetches the herbs matching the current search and filter
+func (a *tuiApp) reload() error {
+	var (
+		herbs []models.Herb
+		err   error
+	)
+	if a.query != "" {
+		herbs, err = a.repos.Herbs.Search(a.query)
+	} else {
+		herbs, err = a.repos.Herbs.GetAll()
+	}
+	if err != nil {
+		return err
+	}
+
+	if a.poisonousOnly {
+		filtered := herbs[:0]
+		for _, herb := range herbs {
+			if herb.IsPoisonous {
+				filtered = append(filtered, herb)
+			}
+		}
+		herbs = filtered
+	}
+
+	a.herbs = herbs
+	a.details = make(map[int]*models.HerbWithDetails)
+	a.selected = min(a.selected, max(len(herbs)-1, 0))
+	return nil
+}
+
+// current returns the selected herb, if any
+func (a *tuiApp) current() *models.Herb {
+	if a.selected < 0 || a.selected >= len(a.herbs) {
+		return nil
+	}
+	return &a.herbs[a.selected]
+}
+
+// handleKey reacts to a key press and reports whether the app should quit
+func (a *tuiApp) handleKey(key tui.Key) bool {
+	if key.Code == tui.KeyCtrlC {
+		return true
+	}
+
+	if a.confirmDelete {
+		a.confirmDelete = false
+		if key.Code == tui.KeyRune && (key.Rune == 'y' || key.Rune == 'Y' || key.Rune == 'д') {
+			a.deleteCurrent()
+		} else {
+			a.status = i18n.T("Удаление отменено.")
+		}
+		return false
+	}
+
+	if a.searching {
+		a.handleSearchKey(key)
+		return false
+	}
+
+	a.status = ""
+	_, height := a.screen.Size()
+	page := max(a.listHeight(height)-1, 1)
+
+	switch key.Code {
+	case tui.KeyUp:
+		a.move(-1)
+	case tui.KeyDown:
+		a.move(1)
+	case tui.KeyPageUp:
+		a.move(-page)
+	case tui.KeyPageDown:
+		a.move(page)
+	case tui.KeyHome:
+		a.move(-len(a.herbs))
+	case tui.KeyEnd:
+		a.move(len(a.herbs))
+	case tui.KeyEscape:
+		if a.query != "" {
+			a.query = ""
+			a.refresh()
+		}
+	case tui.KeyRune:
+		switch key.Rune {
+		case 'q', 'й':
+			return true
+		case 'k', 'л':
+			a.move(-1)
+		case 'j', 'о':
+			a.move(1)
+		case '/':
+			a.searching = true
+		case 'p', 'з':
+			a.poisonousOnly = !a.poisonousOnly
+			a.refresh()
+		case 'r', 'к':
+			a.refresh()
+		case 'e', 'у':
+			a.editCurrent()
+		case 'd', 'в':
+			if herb := a.current(); herb != nil {
+				a.confirmDelete = true
+				a.status = fmt.Sprintf(i18n.T("Удалить «%s» (ID %d)? (y/n)"), herb.Name, herb.ID)
+			}
+		}
+	}
+	return false
+}
+
+// handleSearchKey edits the search query; the list is updated as the user types
+func (a *tuiApp) handleSearchKey(key tui.Key) {
+	switch key.Code {
+	case tui.KeyEnter:
+		a.searching = false
+		return
+	case tui.KeyEscape:
+		a.searching = false
+		a.query = ""
+	case tui.KeyBackspace:
+		if runes := []rune(a.query); len(runes) > 0 {
+			a.query = string(runes[:len(runes)-1])
+		}
+	case tui.KeyRune:
+		a.query += string(key.Rune)
+	default:
+		return
+	}
+	a.selected, a.offset = 0, 0
+	a.refresh()
+}
+
+// refresh reloads the list and reports errors in the status line
+func (a *tuiApp) refresh() {
+	if err := a.reload(); err != nil {
+		a.status = i18n.T("❌ ") + err.Error()
+	}
+}
+
+func (a *tuiApp) move(delta int) {
+	if len(a.herbs) == 0 {
+		return
+	}
+	a.selected = min(max(a.selected+delta, 0), len(a.herbs)-1)
+}
+
+// editCurrent hands the terminal to the editor flow of herb edit
+func (a *tuiApp) editCurrent() {
+	herb := a.current()
+	if herb == nil {
+		return
+	}
+
+	a.screen.Suspend()
+	err := editAndSaveHerb(a.repos.Herbs, herb, false)
+	if err != nil {
+		fmt.Printf(i18n.T("❌ %v\n"), err)
+	}
+	fmt.Print(i18n.T("\nНажмите Enter, чтобы вернуться к списку..."))
+	fmt.Scanln()
+
+	if err := a.screen.Resume(); err != nil {
+		a.status = i18n.T("❌ ") + err.Error()
+		return
+	}
+	a.refresh()
+}
+
+func (a *tuiApp) deleteCurrent() {
+	herb := a.current()
+	if herb == nil {
+		return
+	}
+	if err := a.repos.Herbs.Delete(herb.ID); err != nil {
+		a.status = i18n.T("❌ ") + err.Error()
+		return
+	}
+	a.status = fmt.Sprintf(i18n.T("✅ Трава с ID %d удалена"), herb.ID)
+	a.refresh()
+}
+
+// listHeight is the number of herb rows that fit on a screen of the given height
+func (a *tuiApp) listHeight(height int) int {
+	// Title, column header and the status line take three rows
+	return max(height-3, 1)
+}
+
+func (a *tuiApp) draw() {
+	width, height := a.screen.Size()
+	rows := a.listHeight(height)
+	listWidth := min(max(width*2/5, 30), width)
+	detailWidth := width - listWidth - 3
+
+	// Keep the selected row visible
+	if a.selected < a.offset {
+		a.offset = a.selected
+	}
+	if a.selected >= a.offset+rows {
+		a.offset = a.selected - rows + 1
+	}
+
+	title := fmt.Sprintf(i18n.T(" herbs-cli — трав: %d"), len(a.herbs))
+	if a.query != "" || a.searching {
+		title += fmt.Sprintf(i18n.T("  поиск: %s"), a.query)
+		if a.searching {
+			title += i18n.T("▏")
+		}
+	}
+	if a.poisonousOnly {
+		title += i18n.T("  [только ядовитые]")
+	}
+	a.screen.Line(0, tui.Reverse+tui.Bold+table.Fit(title, width))
+
+	var detail []string
+	if herb := a.current(); herb != nil && detailWidth > 10 {
+		detail = a.detailLines(herb, detailWidth)
+	}
+
+	nameWidth := max((listWidth-12)/2, 8)
+	latinWidth := max(listWidth-nameWidth-12, 0)
+	header := fmt.Sprintf(" %-4s %s %s %s", "ID", table.Fit(i18n.T("Название"), nameWidth), table.Fit(i18n.T("Латинское"), latinWidth), i18n.T("Яд"))
+	a.screen.Line(1, tui.Bold+table.Fit(header, listWidth)+tui.Reset+" │ "+table.Fit(lineAt(detail, 0), detailWidth))
+
+	for row := 0; row < rows; row++ {
+		left := strings.Repeat(" ", listWidth)
+		style := ""
+		if i := a.offset + row; i < len(a.herbs) {
+			herb := a.herbs[i]
+			marker := "  "
+			if herb.IsPoisonous {
+				marker = i18n.T("ДА")
+				style = tui.Red
+			}
+			left = table.Fit(fmt.Sprintf(" %-4d %s %s %s", herb.ID, table.Fit(herb.Name, nameWidth),
+				table.Fit(herb.LatinName, latinWidth), marker), listWidth)
+			if i == a.selected {
+				style = tui.Reverse
+			}
+		}
+		a.screen.Line(row+2, style+left+tui.Reset+" │ "+table.Fit(lineAt(detail, row+1), detailWidth))
+	}
+
+	help := i18n.T(" ↑↓ выбор  / поиск  p ядовитые  e правка  d удалить  r обновить  q выход")
+	if a.status != "" {
+		help = " " + a.status
+	}
+	a.screen.Line(height-1, tui.Reverse+table.Fit(help, width))
+	a.screen.Flush()
+}
+
+// detailLines renders the detail pane for herb, loading its regions and usages on first use
+func (a *tuiApp) detailLines(herb *models.Herb, width int) []string {
+	details, ok := a.details[herb.ID]
+	if !ok {
+		var err error
+		details, err = a.repos.HerbDetails(herb.ID)
+		if err != nil {
+			return table.Wrap(i18n.T("❌ ")+err.Error(), width)
+		}
+		a.details[herb.ID] = details
+	}
+
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(details.Herb.String()), "\n") {
+		lines = append(lines, table.Wrap(line, width)...)
+	}
+
+	if len(details.Regions) > 0 {
+		names := make([]string, len(details.Regions))
+		for i, region := range details.Regions {
+			names[i] = region.Name
+		}
+		lines = append(lines, "")
+		lines = append(lines, table.Wrap(i18n.T("Регионы: ")+strings.Join(names, ", "), width)...)
+	}
+
+	if len(details.Usages) > 0 {
+		lines = append(lines, "", i18n.T("Применение:"))
+		for _, usage := range details.Usages {
+			lines = append(lines, table.Wrap(i18n.T("• ")+usage.UsageTypeName+": "+usageText(usage), width)...)
+		}
+	}
+
+	return lines
+}
+
+// lineAt returns lines[i], or an empty string past the end
+func lineAt(lines []string, i int) string {
+	if i < len(lines) {
+		return lines[i]
+	}
+	return ""
+}
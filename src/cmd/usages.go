@@ -0,0 +1,282 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gloowl/simple_crud/src/internal/database"
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+	"github.com/gloowl/simple_crud/src/internal/models"
+	"github.com/gloowl/simple_crud/src/internal/repository"
+
+	"github.com/spf13/cobra"
+)
+
+// usageCmd groups the commands for herb usages
+var usageCmd = &cobra.Command{
+	Use:   "usage",
+	Short: "Способы применения трав",
+	Long: `Способы применения трав с дозировкой: часть растения, способ приготовления,
+количество с единицей измерения, частота приема и продолжительность курса.`,
+}
+
+// listUsagesCmd lists the usages of herbs
+var listUsagesCmd = &cobra.Command{
+	Use:   "list [HERB_ID...]",
+	Short: "Показать способы применения трав",
+	Long: `Выводит способы применения указанных трав или, без аргументов, всех трав.
+
+С --unit количество переводится в указанную единицу измерения; количество,
+которое нельзя перевести (например, миллилитры в граммы), выводится как есть.`,
+	Example: `  herbs-cli herb usage list 1
+  herbs-cli herb usage list 1 5 --unit mg
+  herbs-cli herb usage list --output json`,
+	RunE: listUsages,
+}
+
+// addUsageCmd adds a usage to a herb
+var addUsageCmd = &cobra.Command{
+	Use:   "add HERB_ID USAGE_TYPE",
+	Short: "Добавить способ применения",
+	Long: `Добавляет траве способ применения. Тип использования задается ID или названием.
+
+Части растения: whole, root, rhizome, bulb, stem, bark, leaf, flower, fruit, berry, seed, sap.
+Способы приготовления: infusion, decoction, tincture, extract, tea, powder, juice,
+oil, ointment, syrup, compress, bath, fresh.
+Единицы измерения: mg, g, kg, ml, l, tsp, tbsp, drop, piece (а также г, мл, ч.л., ст.л. и т.п.).`,
+	Args: cobra.ExactArgs(2),
+	Example: `  herbs-cli herb usage add 1 2 --part flower --preparation infusion --amount 1 --unit tbsp --times 3 --days 14
+  herbs-cli herb usage add 1 "Медицинское" --preparation tincture --amount 20 --unit drop --desc "перед едой"`,
+	RunE: addUsage,
+}
+
+// deleteUsageCmd removes a usage
+var deleteUsageCmd = &cobra.Command{
+	Use:   "delete ID",
+	Short: "Удалить способ применения",
+	Args:  cobra.ExactArgs(1),
+	RunE:  deleteUsage,
+}
+
+func init() {
+	herbCmd.AddCommand(usageCmd)
+	usageCmd.AddCommand(listUsagesCmd)
+	usageCmd.AddCommand(addUsageCmd)
+	usageCmd.AddCommand(deleteUsageCmd)
+
+	listUsagesCmd.Flags().String("unit", "", "перевести количество в единицу измерения: mg, g, kg, ml, l")
+	listUsagesCmd.Flags().StringP("output", "o", "text", "формат вывода (text, json, csv)")
+
+	addUsageCmd.Flags().String("part", "", "часть растения: leaf, flower, root и т.п.")
+	addUsageCmd.Flags().String("preparation", "", "способ приготовления: infusion, decoction, tincture и т.п.")
+	addUsageCmd.Flags().Float64("amount", 0, "количество на один прием")
+	addUsageCmd.Flags().String("unit", "", "единица измерения количества: mg, g, ml, tsp, tbsp, drop и т.п.")
+	addUsageCmd.Flags().Int("times", 0, "сколько раз в день")
+	addUsageCmd.Flags().Int("days", 0, "продолжительность курса в днях")
+	addUsageCmd.Flags().StringP("desc", "d", "", "описание применения")
+}
+
+// usageText describes a usage in one line: the dosage followed by the description
+func usageText(usage models.Usage) string {
+	dosage := usage.Dosage()
+	if dosage == "" {
+		return usage.Description
+	}
+	return dosage + withNote(usage.Description)
+}
+
+// findUsageType finds a usage type by its ID or name (case-insensitive)
+func findUsageType(repo *repository.UsageTypeRepository, arg string) (*models.UsageType, error) {
+	usageTypes, err := repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	id, idErr := strconv.Atoi(arg)
+	for _, usageType := range usageTypes {
+		if (idErr == nil && usageType.ID == id) || strings.EqualFold(usageType.Name, arg) {
+			return &usageType, nil
+		}
+	}
+	return nil, i18n.Errorf("тип использования «%s» не найден", arg)
+}
+
+func listUsages(cmd *cobra.Command, args []string) error {
+	db := database.GetDB()
+	if db == nil {
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+	}
+
+	ids := make([]int, len(args))
+	for i, arg := range args {
+		id, err := strconv.Atoi(arg)
+		if err != nil {
+			return i18n.Errorf("неверный ID: %s", arg)
+		}
+		ids[i] = id
+	}
+
+	unit := ""
+	if value, _ := cmd.Flags().GetString("unit"); value != "" {
+		var err error
+		if unit, err = models.ParseUnit(value); err != nil {
+			return err
+		}
+	}
+
+	usages, err := repository.NewUsageRepository(db).List(ids)
+	if err != nil {
+		return err
+	}
+	if unit != "" {
+		for i := range usages {
+			if models.UnitDimension(usages[i].Unit) == models.UnitDimension(unit) {
+				usages[i].ConvertTo(unit)
+			}
+		}
+	}
+
+	switch format, _ := cmd.Flags().GetString("output"); format {
+	case "", "text":
+	case "json":
+		return writeUsagesJSON(os.Stdout, usages)
+	case "csv":
+		return writeUsagesCSV(os.Stdout, usages)
+	default:
+		return i18n.Errorf("неизвестный формат вывода: %s (доступно: text, json, csv)", format)
+	}
+
+	if len(usages) == 0 {
+		fmt.Println(i18n.T("Способы применения не найдены"))
+		return nil
+	}
+
+	for i, usage := range usages {
+		if i == 0 || usages[i-1].HerbID != usage.HerbID {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Printf("%s [%d]:\n", usage.HerbName, usage.HerbID)
+		}
+		fmt.Printf("  [%d] %s: %s\n", usage.ID, usage.UsageTypeName, usageText(usage))
+	}
+	return nil
+}
+
+func writeUsagesJSON(w io.Writer, usages []models.Usage) error {
+	if usages == nil {
+		usages = []models.Usage{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(usages)
+}
+
+func writeUsagesCSV(w io.Writer, usages []models.Usage) error {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"id", "herb_id", "herb_name", "usage_type", "plant_part", "preparation",
+		"amount", "unit", "times_per_day", "duration_days", "description"})
+
+	for _, usage := range usages {
+		amount := ""
+		if usage.Amount != 0 {
+			amount = models.FormatAmount(usage.Amount)
+		}
+		writer.Write([]string{
+			strconv.Itoa(usage.ID),
+			strconv.Itoa(usage.HerbID),
+			usage.HerbName,
+			usage.UsageTypeName,
+			usage.PlantPart,
+			usage.Preparation,
+			amount,
+			usage.Unit,
+			strconv.Itoa(usage.TimesPerDay),
+			strconv.Itoa(usage.DurationDays),
+			usage.Description,
+		})
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func addUsage(cmd *cobra.Command, args []string) error {
+	db := database.GetDB()
+	if db == nil {
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+	}
+	repos := repository.NewRepositories(db)
+
+	herbID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return i18n.Errorf("неверный ID: %s", args[0])
+	}
+	herb, err := repos.Herbs.GetByID(herbID)
+	if err != nil {
+		return err
+	}
+	usageType, err := findUsageType(repos.UsageTypes, strings.TrimSpace(args[1]))
+	if err != nil {
+		return err
+	}
+
+	part, _ := cmd.Flags().GetString("part")
+	preparation, _ := cmd.Flags().GetString("preparation")
+	amount, _ := cmd.Flags().GetFloat64("amount")
+	times, _ := cmd.Flags().GetInt("times")
+	days, _ := cmd.Flags().GetInt("days")
+	description, _ := cmd.Flags().GetString("desc")
+
+	unit, _ := cmd.Flags().GetString("unit")
+	if unit != "" {
+		if unit, err = models.ParseUnit(unit); err != nil {
+			return err
+		}
+	}
+
+	usage := &models.Usage{
+		HerbID:        herb.ID,
+		UsageTypeID:   usageType.ID,
+		Description:   strings.TrimSpace(description),
+		PlantPart:     strings.ToLower(strings.TrimSpace(part)),
+		Preparation:   strings.ToLower(strings.TrimSpace(preparation)),
+		Amount:        amount,
+		Unit:          unit,
+		TimesPerDay:   times,
+		DurationDays:  days,
+		HerbName:      herb.Name,
+		UsageTypeName: usageType.Name,
+	}
+	if err := repos.Usages.Create(usage); err != nil {
+		return i18n.Errorf("не удалось добавить способ применения: %w", err)
+	}
+
+	fmt.Printf(i18n.T("✅ Траве «%s» добавлен способ применения с ID: %d\n"), herb.Name, usage.ID)
+	fmt.Printf("  %s: %s\n", usage.UsageTypeName, usageText(*usage))
+	return nil
+}
+
+func deleteUsage(cmd *cobra.Command, args []string) error {
+	db := database.GetDB()
+	if db == nil {
+		return i18n.Errorf("❌ нет соединения с БД (database.GetDB() == nil)")
+	}
+
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return i18n.Errorf("неверный ID: %s", args[0])
+	}
+
+	if err := repository.NewUsageRepository(db).Delete(id); err != nil {
+		return err
+	}
+
+	fmt.Printf(i18n.T("✅ Способ применения с ID %d удален\n"), id)
+	return nil
+}
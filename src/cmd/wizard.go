@@ -0,0 +1,286 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+	"github.com/gloowl/simple_crud/src/internal/models"
+	"github.com/gloowl/simple_crud/src/internal/repository"
+)
+
+// errWizardAborted is returned when the user ends the input (Ctrl+D) during the wizard
+var errWizardAborted = i18n.NewError("ввод прерван")
+
+// herbCreateFlags are the flags of herb create; the wizard starts only if none of them is set
+var herbCreateFlags = []string{"name", "latin", "desc", "poisonous", "image", "toxicity", "toxic-parts", "compounds", "symptoms", "flowering", "harvest"}
+
+// herbDraft is everything the create wizard collected
+type herbDraft struct {
+	herb    *models.Herb
+	regions []models.Region
+	usages  []models.Usage
+}
+
+// prompter reads answers to wizard questions from standard input
+type prompter struct {
+	reader *bufio.Reader
+}
+
+func newPrompter() *prompter {
+	return &prompter{reader: bufio.NewReader(os.Stdin)}
+}
+
+// ask prints a question and returns the trimmed answer
+func (p *prompter) ask(question string) (string, error) {
+	fmt.Print(question)
+
+	line, err := p.reader.ReadString('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && line != "") {
+		fmt.Println()
+		return "", errWizardAborted
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// askValid repeats a question until check accepts the answer
+func (p *prompter) askValid(question string, check func(answer string) error) (string, error) {
+	for {
+		answer, err := p.ask(question)
+		if err != nil {
+			return "", err
+		}
+		if err := check(answer); err != nil {
+			fmt.Printf(i18n.T("  ❌ %v\n"), err)
+			continue
+		}
+		return answer, nil
+	}
+}
+
+// askYesNo asks a yes/no question with the given default answer
+func (p *prompter) askYesNo(question string, def bool) (bool, error) {
+	hint := "y/N"
+	if def {
+		hint = "Y/n"
+	}
+
+	for {
+		answer, err := p.ask(fmt.Sprintf("%s (%s): ", question, hint))
+		if err != nil {
+			return false, err
+		}
+		switch strings.ToLower(answer) {
+		case "":
+			return def, nil
+		case "y", "yes", "д", "да":
+			return true, nil
+		case "n", "no", "н", "нет":
+			return false, nil
+		}
+		fmt.Println(i18n.T("  ❌ ответьте y или n"))
+	}
+}
+
+// askIDs asks for a list of IDs, each of which must be present in allowed
+func (p *prompter) askIDs(question string, allowed map[int]bool) ([]int, error) {
+	var ids []int
+	_, err := p.askValid(question, func(answer string) error {
+		if answer == "" {
+			ids = nil
+			return nil
+		}
+		parsed, err := parseIDList([]string{answer})
+		if err != nil {
+			return err
+		}
+		for _, id := range parsed {
+			if !allowed[id] {
+				return i18n.Errorf("нет записи с ID %d", id)
+			}
+		}
+		ids = parsed
+		return nil
+	})
+	return ids, err
+}
+
+// hasHerbCreateFlags reports whether any of the herb create flags was given
+func hasHerbCreateFlags(changed func(name string) bool) bool {
+	for _, name := range herbCreateFlags {
+		if changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// runCreateWizard asks for every herb field, regions and usages, and saves
+// the result in a single transaction after the user confirms it
+func runCreateWizard(repos *repository.Repositories, uow *repository.UnitOfWork) error {
+	p := newPrompter()
+
+	fmt.Println(i18n.T("Создание новой травы. Нажмите Ctrl+D, чтобы прервать."))
+	fmt.Println()
+
+	draft, err := askHerbDraft(p, repos)
+	if errors.Is(err, errWizardAborted) {
+		fmt.Println(i18n.T("Создание отменено."))
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(i18n.T("\nБудет создана трава:"))
+	fmt.Println(draft.herb.String())
+	if len(draft.regions) > 0 {
+		names := make([]string, len(draft.regions))
+		for i, region := range draft.regions {
+			names[i] = region.Name
+		}
+		fmt.Printf(i18n.T("Регионы: %s\n"), strings.Join(names, ", "))
+	}
+	for _, usage := range draft.usages {
+		fmt.Printf(i18n.T("Применение (%s): %s\n"), usage.UsageTypeName, usageText(usage))
+	}
+
+	fmt.Println()
+	ok, err := p.askYesNo(i18n.T("Сохранить?"), true)
+	if err != nil || !ok {
+		fmt.Println(i18n.T("Создание отменено."))
+		return nil
+	}
+
+	err = uow.Do(func(tx *repository.Repositories) error {
+		if err := tx.Herbs.Create(draft.herb); err != nil {
+			return err
+		}
+		for _, region := range draft.regions {
+			if err := tx.Regions.LinkHerb(draft.herb.ID, region.ID); err != nil {
+				return err
+			}
+		}
+		for i := range draft.usages {
+			draft.usages[i].HerbID = draft.herb.ID
+			if err := tx.Usages.Create(&draft.usages[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return i18n.Errorf("не удалось создать траву: %w", err)
+	}
+
+	fmt.Printf(i18n.T("✅ Трава успешно создана с ID: %d\n"), draft.herb.ID)
+	return nil
+}
+
+// askHerbDraft walks the user through all fields of a new herb
+func askHerbDraft(p *prompter, repos *repository.Repositories) (*herbDraft, error) {
+	herb := &models.Herb{CreatedBy: identity}
+
+	// Each field is validated as soon as it is entered; only the errors of
+	// that field are shown, the fields that are not asked yet may still be empty
+	validateWith := func(field string, set func(value string)) func(string) error {
+		return func(answer string) error {
+			set(answer)
+			var errs models.ValidationErrors
+			if err := herb.Validate(); errors.As(err, &errs) {
+				if own := errs.For(field); len(own) > 0 {
+					return own
+				}
+			}
+			return nil
+		}
+	}
+
+	var err error
+	if _, err = p.askValid(i18n.T("Название: "), validateWith("name", func(v string) { herb.Name = v })); err != nil {
+		return nil, err
+	}
+	if _, err = p.askValid(i18n.T("Латинское название (необязательно): "), validateWith("latin_name", func(v string) { herb.LatinName = v })); err != nil {
+		return nil, err
+	}
+	warnLatinName(herb)
+	if _, err = p.askValid(i18n.T("Описание (необязательно): "), validateWith("description", func(v string) { herb.Description = v })); err != nil {
+		return nil, err
+	}
+	question := fmt.Sprintf(i18n.T("Степень токсичности (%s, Enter - none): "), strings.Join(models.ToxicityNames(), ", "))
+	if _, err = p.askValid(question, func(answer string) error {
+		if answer == "" {
+			herb.SetToxicity(models.ToxicityNone)
+			return nil
+		}
+		level, err := models.ParseToxicityLevel(answer)
+		herb.SetToxicity(level)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	if _, err = p.askValid(i18n.T("Путь к изображению (необязательно): "), validateWith("image_path", func(v string) { herb.ImagePath = v })); err != nil {
+		return nil, err
+	}
+
+	draft := &herbDraft{herb: herb}
+
+	regions, err := repos.Regions.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(regions) > 0 {
+		byID := make(map[int]models.Region, len(regions))
+		allowed := make(map[int]bool, len(regions))
+		fmt.Println(i18n.T("\nРегионы произрастания:"))
+		for _, region := range regions {
+			byID[region.ID], allowed[region.ID] = region, true
+			fmt.Printf("  %4d  %s\n", region.ID, region.Name)
+		}
+
+		ids, err := p.askIDs(i18n.T("ID регионов через запятую (Enter - пропустить): "), allowed)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			draft.regions = append(draft.regions, byID[id])
+		}
+	}
+
+	usageTypes, err := repos.UsageTypes.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(usageTypes) > 0 {
+		byID := make(map[int]models.UsageType, len(usageTypes))
+		allowed := make(map[int]bool, len(usageTypes))
+		fmt.Println(i18n.T("\nТипы использования:"))
+		for _, usageType := range usageTypes {
+			byID[usageType.ID], allowed[usageType.ID] = usageType, true
+			fmt.Printf("  %4d  %s\n", usageType.ID, usageType.Name)
+		}
+
+		ids, err := p.askIDs(i18n.T("ID типов использования через запятую (Enter - пропустить): "), allowed)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			description, err := p.ask(fmt.Sprintf(i18n.T("Описание применения (%s): "), byID[id].Name))
+			if err != nil {
+				return nil, err
+			}
+			draft.usages = append(draft.usages, models.Usage{
+				UsageTypeID:   id,
+				Description:   description,
+				HerbName:      herb.Name,
+				UsageTypeName: byID[id].Name,
+			})
+		}
+	}
+
+	return draft, nil
+}
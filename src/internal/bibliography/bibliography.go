@@ -0,0 +1,216 @@
+// Package bibliography exports sources in the formats read by reference managers:
+// BibTeX and CSL-JSON (used by Zotero, Pandoc and citeproc).
+package bibliography
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gloowl/simple_crud/src/internal/models"
+)
+
+// translit spells Russian letters in Latin for citation keys
+var translit = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "i", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ы': "y", 'э': "e", 'ю': "iu", 'я': "ia",
+}
+
+// splitAuthor splits "Фамилия, Имя" into family and given names.
+// A name without a comma is returned as is, as the name of an organization.
+func splitAuthor(author string) (family, given string, ok bool) {
+	family, given, ok = strings.Cut(author, ",")
+	return strings.TrimSpace(family), strings.TrimSpace(given), ok
+}
+
+// Keys returns a citation key for every source: the transliterated family name of
+// the first author followed by the year, e.g. "ivanov2015"; sources without
+// authors are keyed by their ID. The first source with a key keeps it, later ones
+// get the suffixes -2, -3..., e.g. "ivanov2015-2". Keys are made of letters and
+// digits only, so a suffixed key never clashes with the key of another author.
+func Keys(sources []models.Source) []string {
+	keys := make([]string, len(sources))
+	issued := make(map[string]bool, len(sources))
+	for i, source := range sources {
+		key := ""
+		if len(source.Authors) > 0 {
+			family, _, _ := splitAuthor(source.Authors[0])
+			for _, r := range strings.ToLower(family) {
+				switch {
+				case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+					key += string(r)
+				case translit[r] != "":
+					key += translit[r]
+				}
+			}
+		}
+		if key == "" {
+			key = "source" + strconv.Itoa(source.ID)
+		} else if source.Year != 0 {
+			key += strconv.Itoa(source.Year)
+		}
+		unique := key
+		for n := 2; issued[unique]; n++ {
+			unique = key + "-" + strconv.Itoa(n)
+		}
+		issued[unique] = true
+		keys[i] = unique
+	}
+	return keys
+}
+
+// bibtexEscaper escapes the characters that have a special meaning in BibTeX
+var bibtexEscaper = strings.NewReplacer(
+	`\`, `\textbackslash{}`, "{", `\{`, "}", `\}`,
+	"&", `\&`, "%", `\%`, "$", `\$`, "#", `\#`, "_", `\_`,
+)
+
+// WriteBibTeX writes the sources as BibTeX entries
+func WriteBibTeX(w io.Writer, sources []models.Source) error {
+	keys := Keys(sources)
+	for i, source := range sources {
+		entryType, container := "misc", "howpublished"
+		switch source.Kind {
+		case models.SourceBook:
+			entryType = "book"
+		case models.SourceArticle:
+			entryType, container = "article", "journal"
+		}
+
+		var fields [][2]string
+		add := func(name, value string) {
+			if value != "" {
+				fields = append(fields, [2]string{name, value})
+			}
+		}
+		authors := make([]string, len(source.Authors))
+		for j, author := range source.Authors {
+			if _, _, ok := splitAuthor(author); ok {
+				authors[j] = bibtexEscaper.Replace(author)
+			} else {
+				// Braces keep the name of an organization from being split into parts
+				authors[j] = "{" + bibtexEscaper.Replace(author) + "}"
+			}
+		}
+		if len(authors) > 0 {
+			fields = append(fields, [2]string{"author", strings.Join(authors, " and ")})
+		}
+		add("title", bibtexEscaper.Replace(source.Title))
+		add(container, bibtexEscaper.Replace(source.Container))
+		add("publisher", bibtexEscaper.Replace(source.Publisher))
+		if source.Year != 0 {
+			add("year", strconv.Itoa(source.Year))
+		}
+		add("volume", bibtexEscaper.Replace(source.Volume))
+		add("number", bibtexEscaper.Replace(source.Issue))
+		add("pages", bibtexEscaper.Replace(strings.ReplaceAll(source.Pages, "-", "--")))
+		add("doi", source.DOI)
+		add("isbn", source.ISBN)
+		add("url", source.URL)
+
+		if i > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(w, "@%s{%s,\n", entryType, keys[i]); err != nil {
+			return err
+		}
+		for j, field := range fields {
+			separator := ","
+			if j == len(fields)-1 {
+				separator = ""
+			}
+			if _, err := fmt.Fprintf(w, "  %-9s = {%s}%s\n", field[0], field[1], separator); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintln(w, "}"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cslName is a name in CSL-JSON: a person with family and given names
+// or an organization given literally
+type cslName struct {
+	Family  string `json:"family,omitempty"`
+	Given   string `json:"given,omitempty"`
+	Literal string `json:"literal,omitempty"`
+}
+
+// cslDate is a date in CSL-JSON; only the year is known for sources
+type cslDate struct {
+	DateParts [][]int `json:"date-parts"`
+}
+
+// cslItem is a single reference in CSL-JSON
+type cslItem struct {
+	ID             string    `json:"id"`
+	Type           string    `json:"type"`
+	Title          string    `json:"title"`
+	Author         []cslName `json:"author,omitempty"`
+	Issued         *cslDate  `json:"issued,omitempty"`
+	ContainerTitle string    `json:"container-title,omitempty"`
+	Publisher      string    `json:"publisher,omitempty"`
+	Volume         string    `json:"volume,omitempty"`
+	Issue          string    `json:"issue,omitempty"`
+	Page           string    `json:"page,omitempty"`
+	DOI            string    `json:"DOI,omitempty"`
+	ISBN           string    `json:"ISBN,omitempty"`
+	URL            string    `json:"URL,omitempty"`
+}
+
+// cslTypes maps the kinds of sources onto CSL item types
+var cslTypes = map[string]string{
+	models.SourceBook:    "book",
+	models.SourceArticle: "article-journal",
+	models.SourceWebsite: "webpage",
+	models.SourceOther:   "document",
+}
+
+// WriteCSLJSON writes the sources as a CSL-JSON array
+func WriteCSLJSON(w io.Writer, sources []models.Source) error {
+	keys := Keys(sources)
+	items := make([]cslItem, len(sources))
+	for i, source := range sources {
+		item := cslItem{
+			ID:             keys[i],
+			Type:           cslTypes[source.Kind],
+			Title:          source.Title,
+			ContainerTitle: source.Container,
+			Publisher:      source.Publisher,
+			Volume:         source.Volume,
+			Issue:          source.Issue,
+			Page:           source.Pages,
+			DOI:            source.DOI,
+			ISBN:           source.ISBN,
+			URL:            source.URL,
+		}
+		if item.Type == "" {
+			item.Type = "document"
+		}
+		for _, author := range source.Authors {
+			if family, given, ok := splitAuthor(author); ok {
+				item.Author = append(item.Author, cslName{Family: family, Given: given})
+			} else {
+				item.Author = append(item.Author, cslName{Literal: author})
+			}
+		}
+		if source.Year != 0 {
+			item.Issued = &cslDate{DateParts: [][]int{{source.Year}}}
+		}
+		items[i] = item
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(items)
+}
@@ -0,0 +1,72 @@
+package bibliography
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/gloowl/simple_crud/src/internal/models"
+)
+
+func TestKeys(t *testing.T) {
+	source := func(id int, year int, authors ...string) models.Source {
+		return models.Source{ID: id, Year: year, Authors: authors}
+	}
+
+	tests := []struct {
+		name    string
+		sources []models.Source
+		want    []string
+	}{
+		{
+			name:    "transliterated family name and year",
+			sources: []models.Source{source(1, 2015, "Иванов, И. И."), source(2, 2019, "Smith, J.", "Иванов, И. И.")},
+			want:    []string{"ivanov2015", "smith2019"},
+		},
+		{
+			name:    "organization and no year",
+			sources: []models.Source{source(1, 0, "ВОЗ"), source(2, 2020, "World Health Organization")},
+			want:    []string{"voz", "worldhealthorganization2020"},
+		},
+		{
+			name:    "no authors",
+			sources: []models.Source{source(7, 2015), source(8, 2015, "!!!")},
+			want:    []string{"source7", "source8"},
+		},
+		{
+			name:    "repeated keys",
+			sources: []models.Source{source(1, 2015, "Иванов, И."), source(2, 2015, "Ivanov, I."), source(3, 2015, "Иванов, П.")},
+			want:    []string{"ivanov2015", "ivanov2015-2", "ivanov2015-3"},
+		},
+		{
+			name:    "suffix does not run into another family name",
+			sources: []models.Source{source(1, 0, "Иванов, И."), source(2, 0, "Иванов, П."), source(3, 0, "Иванова, А.")},
+			want:    []string{"ivanov", "ivanov-2", "ivanova"},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := Keys(tt.sources); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Keys() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestKeysUnique(t *testing.T) {
+	sources := make([]models.Source, 30)
+	for i := range sources {
+		sources[i] = models.Source{ID: i + 1, Year: 2015, Authors: []string{"Иванов, И."}}
+	}
+
+	keys := Keys(sources)
+	seen := make(map[string]bool)
+	for i, key := range keys {
+		if seen[key] {
+			t.Fatalf("Keys() issued %q twice", key)
+		}
+		seen[key] = true
+		if want := "ivanov2015-" + strconv.Itoa(i+1); i > 0 && key != want {
+			t.Errorf("key %d = %q, want %q", i, key, want)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+// Package binomial parses and normalizes botanical Latin names such as
+// "Matricaria chamomilla L." or "Matricaria chamomilla var. recutita (L.) Rauschert".
+package binomial
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+)
+
+// Name is a parsed Latin plant name
+type Name struct {
+	Genus   string
+	Hybrid  bool   // the species is a hybrid, written with "×"
+	Species string // species epithet
+	// SpeciesAuthorship is the author citation of the species when the name
+	// has an infraspecific rank, e.g. "L." in "Matricaria chamomilla L. var. recutita"
+	SpeciesAuthorship string
+	Rank              string // infraspecific rank: subsp., var., subvar., f. or subf.
+	Epithet           string // infraspecific epithet
+	Authorship        string // author citation of the most specific part of the name
+
+	// unspecified keeps "sp." or "spp." written instead of the species epithet
+	unspecified string
+}
+
+// hybridSign marks hybrid species
+const hybridSign = "×"
+
+// ranks maps the accepted spellings of infraspecific ranks to their standard abbreviation
+var ranks = map[string]string{
+	"subsp.": "subsp.", "subsp": "subsp.", "ssp.": "subsp.", "ssp": "subsp.",
+	"var.": "var.", "var": "var.",
+	"subvar.": "subvar.", "subvar": "subvar.",
+	"f.": "f.", "fo.": "f.", "forma": "f.",
+	"subf.": "subf.", "subf": "subf.",
+}
+
+// Parse splits a Latin name into its parts, normalizing capitalization and whitespace.
+// Problems that make the name a malformed binomial are returned as warnings;
+// the name is still parsed as well as possible.
+func Parse(s string) (Name, []string) {
+	var (
+		name     Name
+		warnings []string
+	)
+	warn := func(format string, args ...any) {
+		warnings = append(warnings, fmt.Sprintf(i18n.T(format), args...))
+	}
+
+	tokens := strings.Fields(s)
+	if len(tokens) == 0 {
+		return name, nil
+	}
+
+	name.Genus = capitalize(tokens[0])
+	if !isEpithet(tokens[0]) || !isLatin(tokens[0]) {
+		warn("род «%s» должен состоять только из латинских букв", tokens[0])
+	}
+	rest := tokens[1:]
+
+	if len(rest) > 0 && isHybridSign(rest[0]) {
+		name.Hybrid = true
+		rest = rest[1:]
+	}
+
+	switch {
+	case len(rest) > 0 && isEpithet(rest[0]) && !isRank(rest[0]):
+		name.Species = strings.ToLower(rest[0])
+		rest = rest[1:]
+	case len(rest) > 0 && (rest[0] == "sp." || rest[0] == "spp."):
+		warn("не указан видовой эпитет (%s)", rest[0])
+		name.unspecified = rest[0]
+		rest = rest[1:]
+	default:
+		warn("не указан видовой эпитет: ожидается название вида из рода и эпитета, например «Matricaria chamomilla»")
+	}
+
+	// Everything up to an infraspecific rank is the author citation of the species
+	authorship := rest
+	for i, token := range rest {
+		if !isRank(token) {
+			continue
+		}
+		name.SpeciesAuthorship = strings.Join(rest[:i], " ")
+		name.Rank = ranks[strings.ToLower(token)]
+		authorship = rest[i+1:]
+		if len(authorship) > 0 && isEpithet(authorship[0]) {
+			name.Epithet = strings.ToLower(authorship[0])
+			authorship = authorship[1:]
+		} else {
+			warn("после ранга %s не указан эпитет", name.Rank)
+		}
+		break
+	}
+	name.Authorship = strings.Join(authorship, " ")
+
+	if name.Species == "" && name.Hybrid {
+		warn("после знака гибрида %s не указан эпитет", hybridSign)
+	}
+	if !isLatin(name.Species) || !isLatin(name.Epithet) {
+		warn("эпитет должен состоять только из латинских букв")
+	}
+
+	return name, warnings
+}
+
+// String returns the normalized name
+func (n Name) String() string {
+	var parts []string
+	add := func(part string) {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	add(n.Genus)
+	if n.Hybrid {
+		add(hybridSign)
+	}
+	add(n.Species)
+	add(n.unspecified)
+	add(n.SpeciesAuthorship)
+	add(n.Rank)
+	add(n.Epithet)
+	add(n.Authorship)
+	return strings.Join(parts, " ")
+}
+
+// Key returns the lowercased name without the hybrid sign and author citations,
+// so that spellings of the same taxon by different authors share one key.
+// It mirrors latin_name_key in the database.
+func (n Name) Key() string {
+	var parts []string
+	for _, part := range []string{n.Genus, n.Species, n.unspecified, n.Rank, n.Epithet} {
+		if part != "" {
+			parts = append(parts, strings.ToLower(part))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// capitalize returns word with the first letter in upper case and the rest in lower case
+func capitalize(word string) string {
+	first, size := utf8.DecodeRuneInString(word)
+	return string(unicode.ToUpper(first)) + strings.ToLower(word[size:])
+}
+
+// isEpithet reports whether token is a word made of letters and hyphens
+func isEpithet(token string) bool {
+	for _, r := range token {
+		if !unicode.IsLetter(r) && r != '-' {
+			return false
+		}
+	}
+	return token != "" && token[0] != '-'
+}
+
+// isLatin reports whether word uses only letters of the Latin alphabet
+func isLatin(word string) bool {
+	for _, r := range word {
+		if unicode.IsLetter(r) && !unicode.Is(unicode.Latin, r) {
+			return false
+		}
+	}
+	return true
+}
+
+func isRank(token string) bool {
+	_, ok := ranks[strings.ToLower(token)]
+	return ok
+}
+
+func isHybridSign(token string) bool {
+	return token == hybridSign || token == "x" || token == "X"
+}
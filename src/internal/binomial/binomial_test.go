@@ -0,0 +1,109 @@
+package binomial
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in       string
+		want     Name
+		str      string
+		warnings int
+	}{
+		{
+			in:   "Matricaria chamomilla L.",
+			want: Name{Genus: "Matricaria", Species: "chamomilla", Authorship: "L."},
+			str:  "Matricaria chamomilla L.",
+		},
+		{
+			in:   "  matricaria CHAMOMILLA\tL. ",
+			want: Name{Genus: "Matricaria", Species: "chamomilla", Authorship: "L."},
+			str:  "Matricaria chamomilla L.",
+		},
+		{
+			in: "Matricaria chamomilla L. var. recutita (L.) Rauschert",
+			want: Name{
+				Genus: "Matricaria", Species: "chamomilla", SpeciesAuthorship: "L.",
+				Rank: "var.", Epithet: "recutita", Authorship: "(L.) Rauschert",
+			},
+			str: "Matricaria chamomilla L. var. recutita (L.) Rauschert",
+		},
+		{
+			in:   "Achillea millefolium ssp. collina",
+			want: Name{Genus: "Achillea", Species: "millefolium", Rank: "subsp.", Epithet: "collina"},
+			str:  "Achillea millefolium subsp. collina",
+		},
+		{
+			in:   "Mentha x piperita L.",
+			want: Name{Genus: "Mentha", Hybrid: true, Species: "piperita", Authorship: "L."},
+			str:  "Mentha × piperita L.",
+		},
+		{
+			in:       "Mentha sp.",
+			want:     Name{Genus: "Mentha", unspecified: "sp."},
+			str:      "Mentha sp.",
+			warnings: 1,
+		},
+		{
+			in:       "Mentha",
+			want:     Name{Genus: "Mentha"},
+			str:      "Mentha",
+			warnings: 1,
+		},
+		{
+			in:       "Mentha var.",
+			want:     Name{Genus: "Mentha", Rank: "var."},
+			str:      "Mentha var.",
+			warnings: 2,
+		},
+		{
+			in:       "Ромашка аптечная",
+			want:     Name{Genus: "Ромашка", Species: "аптечная"},
+			str:      "Ромашка аптечная",
+			warnings: 2,
+		},
+		{
+			in:   "",
+			want: Name{},
+			str:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		got, warnings := Parse(tt.in)
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+		if got.String() != tt.str {
+			t.Errorf("Parse(%q).String() = %q, want %q", tt.in, got.String(), tt.str)
+		}
+		if len(warnings) != tt.warnings {
+			t.Errorf("Parse(%q) returned %d warnings %q, want %d", tt.in, len(warnings), warnings, tt.warnings)
+		}
+	}
+}
+
+func TestKey(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"Matricaria chamomilla", "matricaria chamomilla"},
+		{"Matricaria chamomilla L.", "matricaria chamomilla"},
+		{"matricaria  CHAMOMILLA Rauschert", "matricaria chamomilla"},
+		{"Matricaria chamomilla L. var. recutita (L.) Rauschert", "matricaria chamomilla var. recutita"},
+		{"Matricaria chamomilla variety recutita", "matricaria chamomilla"},
+		{"Matricaria chamomilla Var recutita", "matricaria chamomilla var. recutita"},
+		{"Mentha × piperita L.", "mentha piperita"},
+		{"Mentha X piperita", "mentha piperita"},
+		{"Mentha spp.", "mentha spp."},
+		{"Mentha", "mentha"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		name, _ := Parse(tt.in)
+		if got := name.Key(); got != tt.want {
+			t.Errorf("Parse(%q).Key() = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
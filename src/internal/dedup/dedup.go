@@ -0,0 +1,135 @@
+package dedup
+
+import (
+	"fmt"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+	"github.com/gloowl/simple_crud/src/internal/models"
+)
+
+// minFuzzyLength is the shortest normalized latin name that is compared fuzzily;
+// shorter names differ too little to tell a typo from a different plant
+const minFuzzyLength = 6
+
+// Group is a set of herbs that are likely duplicates of each other
+type Group struct {
+	Herbs   []models.Herb
+	Reasons []string
+}
+
+// FindDuplicates groups herbs that have the same normalized name or latin name,
+// or whose latin names differ by at most maxDistance edits.
+// Groups are ordered by the smallest herb ID they contain.
+func FindDuplicates(herbs []models.Herb, maxDistance int) []Group {
+	parent := make([]int, len(herbs))
+	for i := range parent {
+		parent[i] = i
+	}
+
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	names := make([]string, len(herbs))
+	latins := make([]string, len(herbs))
+	for i := range herbs {
+		names[i] = models.NormalizeKey(herbs[i].Name)
+		latins[i] = models.NormalizeKey(herbs[i].LatinName)
+	}
+
+	reasons := make(map[int][]string)
+	for i := range herbs {
+		for j := i + 1; j < len(herbs); j++ {
+			reason := matchReason(names[i], names[j], latins[i], latins[j], maxDistance)
+			if reason == "" {
+				continue
+			}
+
+			reason = fmt.Sprintf(i18n.T("ID %d и ID %d: %s"), herbs[i].ID, herbs[j].ID, reason)
+			ri, rj := find(i), find(j)
+			if ri != rj {
+				parent[rj] = ri
+				reasons[ri] = append(reasons[ri], reasons[rj]...)
+				delete(reasons, rj)
+			}
+			reasons[ri] = append(reasons[ri], reason)
+		}
+	}
+
+	members := make(map[int][]models.Herb)
+	for i := range herbs {
+		root := find(i)
+		if _, ok := reasons[root]; ok {
+			members[root] = append(members[root], herbs[i])
+		}
+	}
+
+	groups := make([]Group, 0, len(members))
+	for root, list := range members {
+		sort.Slice(list, func(a, b int) bool { return list[a].ID < list[b].ID })
+		groups = append(groups, Group{Herbs: list, Reasons: reasons[root]})
+	}
+	sort.Slice(groups, func(a, b int) bool { return groups[a].Herbs[0].ID < groups[b].Herbs[0].ID })
+
+	return groups
+}
+
+// matchReason explains why two herbs look like duplicates, or returns "" if they do not
+func matchReason(nameA, nameB, latinA, latinB string, maxDistance int) string {
+	if latinA != "" && latinA == latinB {
+		return i18n.T("одинаковое латинское название")
+	}
+	if nameA != "" && nameA == nameB {
+		return i18n.T("одинаковое название")
+	}
+	if latinA == "" || latinB == "" || maxDistance <= 0 {
+		return ""
+	}
+	if utf8.RuneCountInString(latinA) < minFuzzyLength || utf8.RuneCountInString(latinB) < minFuzzyLength {
+		return ""
+	}
+	if d := levenshtein(latinA, latinB, maxDistance); d <= maxDistance {
+		return fmt.Sprintf(i18n.T("похожие латинские названия (отличие в %d симв.)"), d)
+	}
+	return ""
+}
+
+// levenshtein returns the edit distance between a and b counted in runes.
+// Once the distance is known to exceed limit, limit+1 is returned early.
+func levenshtein(a, b string, limit int) int {
+	ra, rb := []rune(a), []rune(b)
+	if diff := len(ra) - len(rb); diff > limit || -diff > limit {
+		return limit + 1
+	}
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			rowMin = min(rowMin, curr[j])
+		}
+		if rowMin > limit {
+			return limit + 1
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
@@ -0,0 +1,145 @@
+package dedup
+
+import (
+	"testing"
+
+	"github.com/gloowl/simple_crud/src/internal/models"
+)
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		limit int
+		want  int
+	}{
+		{"", "", 2, 0},
+		{"mentha", "mentha", 2, 0},
+		{"kitten", "sitting", 5, 3},
+		{"kitten", "sitting", 1, 2},
+		{"mentha", "mentha piperita", 3, 4},
+		{"ромашка", "ромашки", 2, 1},
+		{"ab", "ba", 2, 2},
+	}
+
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b, tt.limit); got != tt.want {
+			t.Errorf("levenshtein(%q, %q, %d) = %d, want %d", tt.a, tt.b, tt.limit, got, tt.want)
+		}
+	}
+}
+
+func groupIDs(groups []Group) [][]int {
+	ids := make([][]int, len(groups))
+	for i, g := range groups {
+		for _, h := range g.Herbs {
+			ids[i] = append(ids[i], h.ID)
+		}
+	}
+	return ids
+}
+
+func equalIDs(a, b [][]int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if len(a[i]) != len(b[i]) {
+			return false
+		}
+		for j := range a[i] {
+			if a[i][j] != b[i][j] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestFindDuplicates(t *testing.T) {
+	tests := []struct {
+		name        string
+		herbs       []models.Herb
+		maxDistance int
+		want        [][]int
+	}{
+		{
+			name: "same latin name after normalization",
+			herbs: []models.Herb{
+				{ID: 1, Name: "Мята", LatinName: "Mentha  piperita"},
+				{ID: 2, Name: "Мята перечная", LatinName: "mentha piperita"},
+				{ID: 3, Name: "Шалфей", LatinName: "Salvia officinalis"},
+			},
+			want: [][]int{{1, 2}},
+		},
+		{
+			name: "same name without latin name",
+			herbs: []models.Herb{
+				{ID: 4, Name: "Ромашка"},
+				{ID: 2, Name: " ромашка "},
+				{ID: 3, Name: "Чабрец"},
+			},
+			want: [][]int{{2, 4}},
+		},
+		{
+			name: "fuzzy matches are clustered transitively",
+			herbs: []models.Herb{
+				{ID: 1, Name: "А", LatinName: "Hypericum perforatum"},
+				{ID: 2, Name: "Б", LatinName: "Hypericum perforatun"},
+				{ID: 3, Name: "В", LatinName: "Hypericum perforaxun"},
+				{ID: 4, Name: "Г", LatinName: "Achillea millefolium"},
+			},
+			maxDistance: 1,
+			want:        [][]int{{1, 2, 3}},
+		},
+		{
+			name: "fuzzy matching disabled",
+			herbs: []models.Herb{
+				{ID: 1, Name: "А", LatinName: "Hypericum perforatum"},
+				{ID: 2, Name: "Б", LatinName: "Hypericum perforatun"},
+			},
+			want: [][]int{},
+		},
+		{
+			name: "distance above the limit",
+			herbs: []models.Herb{
+				{ID: 1, Name: "А", LatinName: "Hypericum perforatum"},
+				{ID: 2, Name: "Б", LatinName: "Hypericum perfolatun"},
+			},
+			maxDistance: 1,
+			want:        [][]int{},
+		},
+		{
+			name: "short latin names are not compared fuzzily",
+			herbs: []models.Herb{
+				{ID: 1, Name: "А", LatinName: "Ruta"},
+				{ID: 2, Name: "Б", LatinName: "Rubus"},
+			},
+			maxDistance: 2,
+			want:        [][]int{},
+		},
+		{
+			name: "groups are ordered by smallest ID",
+			herbs: []models.Herb{
+				{ID: 9, Name: "Чабрец"},
+				{ID: 5, Name: "Ромашка"},
+				{ID: 7, Name: "чабрец"},
+				{ID: 3, Name: "ромашка"},
+			},
+			want: [][]int{{3, 5}, {7, 9}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groups := FindDuplicates(tt.herbs, tt.maxDistance)
+			if got := groupIDs(groups); !equalIDs(got, tt.want) {
+				t.Errorf("FindDuplicates() = %v, want %v", got, tt.want)
+			}
+			for _, g := range groups {
+				if len(g.Reasons) < len(g.Herbs)-1 {
+					t.Errorf("group %v has %d reasons, want at least %d", groupIDs([]Group{g}), len(g.Reasons), len(g.Herbs)-1)
+				}
+			}
+		})
+	}
+}
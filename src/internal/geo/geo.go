@@ -0,0 +1,269 @@
+// Package geo reads region boundaries in GeoJSON and finds the boundaries that
+// contain a point. Coordinates are treated as planar longitude and latitude, so
+// a boundary that crosses the antimeridian must be split into polygons on either
+// side of it, as RFC 7946 recommends.
+package geo
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+)
+
+// Point is a position in GeoJSON order: longitude, then latitude
+type Point [2]float64
+
+// Ring is a closed line; its first and last points are the same
+type Ring []Point
+
+// Polygon is an outer ring followed by the rings of its holes
+type Polygon []Ring
+
+// Geometry is a boundary made of one or more polygons
+type Geometry struct {
+	Polygons []Polygon
+}
+
+// object holds the members of any GeoJSON object used by Parse
+type object struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+	Geometry    json.RawMessage `json:"geometry"`
+	Features    []object        `json:"features"`
+}
+
+// Parse reads a Polygon or MultiPolygon given as a geometry, a Feature or
+// a FeatureCollection; the polygons of all features are joined together
+func Parse(data []byte) (*Geometry, error) {
+	var obj object
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, i18n.Errorf("ошибка разбора GeoJSON: %v", err)
+	}
+
+	geometry := &Geometry{}
+	if err := geometry.add(obj); err != nil {
+		return nil, err
+	}
+	if len(geometry.Polygons) == 0 {
+		return nil, i18n.Errorf("в GeoJSON нет многоугольников")
+	}
+	if err := geometry.Validate(); err != nil {
+		return nil, err
+	}
+	return geometry, nil
+}
+
+// add appends the polygons of a GeoJSON object
+func (g *Geometry) add(obj object) error {
+	switch obj.Type {
+	case "Polygon":
+		var polygon Polygon
+		if err := json.Unmarshal(obj.Coordinates, &polygon); err != nil {
+			return i18n.Errorf("неверные координаты Polygon: %v", err)
+		}
+		g.Polygons = append(g.Polygons, polygon)
+	case "MultiPolygon":
+		var polygons []Polygon
+		if err := json.Unmarshal(obj.Coordinates, &polygons); err != nil {
+			return i18n.Errorf("неверные координаты MultiPolygon: %v", err)
+		}
+		g.Polygons = append(g.Polygons, polygons...)
+	case "Feature":
+		if len(obj.Geometry) == 0 || string(obj.Geometry) == "null" {
+			return nil
+		}
+		var geometry object
+		if err := json.Unmarshal(obj.Geometry, &geometry); err != nil {
+			return i18n.Errorf("ошибка разбора GeoJSON: %v", err)
+		}
+		return g.add(geometry)
+	case "FeatureCollection":
+		for _, feature := range obj.Features {
+			if err := g.add(feature); err != nil {
+				return err
+			}
+		}
+	default:
+		return i18n.Errorf("неподдерживаемый тип GeoJSON: %s (доступно: Polygon, MultiPolygon, Feature, FeatureCollection)", obj.Type)
+	}
+	return nil
+}
+
+// Validate checks that every ring is closed, has at least four points, that all
+// coordinates are valid longitudes and latitudes and that no edge crosses the
+// antimeridian
+func (g *Geometry) Validate() error {
+	for _, polygon := range g.Polygons {
+		if len(polygon) == 0 {
+			return i18n.Errorf("у многоугольника нет внешнего контура")
+		}
+		for _, ring := range polygon {
+			if len(ring) < 4 {
+				return i18n.Errorf("контур многоугольника должен содержать не менее 4 точек")
+			}
+			if ring[0] != ring[len(ring)-1] {
+				return i18n.Errorf("контур многоугольника должен быть замкнут")
+			}
+			for i, p := range ring {
+				if !ValidPoint(p) {
+					return i18n.Errorf("неверные координаты: %g, %g", p[0], p[1])
+				}
+				if i > 0 && crossesAntimeridian(ring[i-1], p) {
+					return i18n.Errorf("контур пересекает антимеридиан между долготами %g и %g; разделите его на многоугольники по обе стороны от долготы 180", ring[i-1][0], p[0])
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// crossesAntimeridian reports whether the edge from a to b is meant to cross
+// longitude ±180: an edge longer than half of the globe goes the short way
+// round, which planar coordinates cannot express. An edge between -180 and 180,
+// such as the bottom of a box around the whole globe, is taken as drawn.
+func crossesAntimeridian(a, b Point) bool {
+	return math.Abs(a[0]-b[0]) > 180 && !(math.Abs(a[0]) == 180 && math.Abs(b[0]) == 180)
+}
+
+// ValidPoint reports whether p has a longitude within ±180 and a latitude within ±90
+func ValidPoint(p Point) bool {
+	return p[0] >= -180 && p[0] <= 180 && p[1] >= -90 && p[1] <= 90
+}
+
+// Contains reports whether p lies inside any of the polygons and outside of their
+// holes. The boundary belongs to the region: points on an edge or a vertex of
+// a polygon or of one of its holes are inside.
+func (g *Geometry) Contains(p Point) bool {
+	for _, polygon := range g.Polygons {
+		if len(polygon) == 0 {
+			continue
+		}
+		if polygon[0].onEdge(p) {
+			return true
+		}
+		if !polygon[0].contains(p) {
+			continue
+		}
+		inHole := false
+		for _, hole := range polygon[1:] {
+			inHole = inHole || (hole.contains(p) && !hole.onEdge(p))
+		}
+		if !inHole {
+			return true
+		}
+	}
+	return false
+}
+
+// contains casts a ray from p towards increasing longitude and counts
+// how many edges of the ring it crosses: an odd number means p is inside
+func (r Ring) contains(p Point) bool {
+	inside := false
+	for i, j := 0, len(r)-1; i < len(r); j, i = i, i+1 {
+		a, b := r[i], r[j]
+		if (a[1] > p[1]) != (b[1] > p[1]) &&
+			p[0] < (b[0]-a[0])*(p[1]-a[1])/(b[1]-a[1])+a[0] {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// edgeTolerance is the distance in degrees (about 0.1 mm) within which a point
+// is considered to lie on an edge
+const edgeTolerance = 1e-9
+
+// onEdge reports whether p lies on one of the edges of the ring
+func (r Ring) onEdge(p Point) bool {
+	for i := 0; i+1 < len(r); i++ {
+		a, b := r[i], r[i+1]
+		if p[0] < math.Min(a[0], b[0])-edgeTolerance || p[0] > math.Max(a[0], b[0])+edgeTolerance ||
+			p[1] < math.Min(a[1], b[1])-edgeTolerance || p[1] > math.Max(a[1], b[1])+edgeTolerance {
+			continue
+		}
+		// The cross product is the distance from p to the line ab times its length
+		cross := (b[0]-a[0])*(p[1]-a[1]) - (b[1]-a[1])*(p[0]-a[0])
+		if math.Abs(cross) <= edgeTolerance*math.Hypot(b[0]-a[0], b[1]-a[1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// Area returns the planar area of the geometry in square degrees without its holes.
+// It is only meant for comparing regions, e.g. to list the smallest one first.
+func (g *Geometry) Area() float64 {
+	area := 0.0
+	for _, polygon := range g.Polygons {
+		for i, ring := range polygon {
+			if i == 0 {
+				area += ring.area()
+			} else {
+				area -= ring.area()
+			}
+		}
+	}
+	return area
+}
+
+// area computes the area of the ring with the shoelace formula
+func (r Ring) area() float64 {
+	sum := 0.0
+	for i := 0; i+1 < len(r); i++ {
+		sum += r[i][0]*r[i+1][1] - r[i+1][0]*r[i][1]
+	}
+	return math.Abs(sum) / 2
+}
+
+// MarshalJSON writes the geometry as a GeoJSON Polygon, or as a MultiPolygon
+// if it has several polygons
+func (g *Geometry) MarshalJSON() ([]byte, error) {
+	if len(g.Polygons) == 1 {
+		return json.Marshal(struct {
+			Type        string  `json:"type"`
+			Coordinates Polygon `json:"coordinates"`
+		}{"Polygon", g.Polygons[0]})
+	}
+	return json.Marshal(struct {
+		Type        string    `json:"type"`
+		Coordinates []Polygon `json:"coordinates"`
+	}{"MultiPolygon", g.Polygons})
+}
+
+// Feature is a GeoJSON feature; a nil Geometry is written as null
+type Feature struct {
+	ID         int            `json:"id"`
+	Geometry   *Geometry      `json:"geometry"`
+	Properties map[string]any `json:"properties"`
+}
+
+// MarshalJSON adds the type member required by GeoJSON. Like
+// WriteFeatureCollection it leaves <, > and & in the properties unescaped.
+func (f Feature) MarshalJSON() ([]byte, error) {
+	type feature Feature
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	err := encoder.Encode(struct {
+		Type string `json:"type"`
+		feature
+	}{"Feature", feature(f)})
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), err
+}
+
+// WriteFeatureCollection writes the features as a compact GeoJSON FeatureCollection;
+// boundaries have too many points to be indented
+func WriteFeatureCollection(w io.Writer, features []Feature) error {
+	if features == nil {
+		features = []Feature{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(struct {
+		Type     string    `json:"type"`
+		Features []Feature `json:"features"`
+	}{"FeatureCollection", features})
+}
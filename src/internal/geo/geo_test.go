@@ -0,0 +1,204 @@
+package geo
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const (
+	square     = `[[0,0],[10,0],[10,10],[0,10],[0,0]]`
+	squareHole = `[[4,4],[6,4],[6,6],[4,6],[4,4]]`
+	// notch is a square with a V cut into its top edge down to (5,5)
+	notch = `[[0,0],[10,0],[10,10],[5,5],[0,10],[0,0]]`
+	// east and west are the two halves of a region across the antimeridian
+	east = `[[170,60],[180,60],[180,70],[170,70],[170,60]]`
+	west = `[[-180,60],[-170,60],[-170,70],[-180,70],[-180,60]]`
+)
+
+func polygonJSON(rings ...string) string {
+	return `{"type":"Polygon","coordinates":[` + strings.Join(rings, ",") + `]}`
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		polygons int
+		wantErr  bool
+	}{
+		{name: "polygon", data: polygonJSON(square), polygons: 1},
+		{name: "polygon with hole", data: polygonJSON(square, squareHole), polygons: 1},
+		{name: "multipolygon", data: `{"type":"MultiPolygon","coordinates":[[` + square + `],[` + notch + `]]}`, polygons: 2},
+		{name: "feature", data: `{"type":"Feature","geometry":` + polygonJSON(square) + `,"properties":{}}`, polygons: 1},
+		{
+			name: "feature collection",
+			data: `{"type":"FeatureCollection","features":[
+				{"type":"Feature","geometry":` + polygonJSON(square) + `},
+				{"type":"Feature","geometry":null},
+				{"type":"Feature","geometry":{"type":"MultiPolygon","coordinates":[[` + east + `],[` + west + `]]}}]}`,
+			polygons: 3,
+		},
+		{name: "whole globe", data: polygonJSON(`[[-180,-90],[180,-90],[180,90],[-180,90],[-180,-90]]`), polygons: 1},
+		{name: "split at the antimeridian", data: `{"type":"MultiPolygon","coordinates":[[` + east + `],[` + west + `]]}`, polygons: 2},
+
+		{name: "invalid JSON", data: `{"type":`, wantErr: true},
+		{name: "unsupported type", data: `{"type":"Point","coordinates":[1,2]}`, wantErr: true},
+		{name: "bad coordinates", data: `{"type":"Polygon","coordinates":[[1,2]]}`, wantErr: true},
+		{name: "no polygons", data: `{"type":"FeatureCollection","features":[{"type":"Feature","geometry":null}]}`, wantErr: true},
+		{name: "no outer ring", data: `{"type":"Polygon","coordinates":[]}`, wantErr: true},
+		{name: "open ring", data: polygonJSON(`[[0,0],[10,0],[10,10],[0,10]]`), wantErr: true},
+		{name: "open hole", data: polygonJSON(square, `[[4,4],[6,4],[6,6],[4,6]]`), wantErr: true},
+		{name: "too few points", data: polygonJSON(`[[0,0],[10,0],[0,0]]`), wantErr: true},
+		{name: "latitude out of range", data: polygonJSON(`[[0,0],[10,0],[10,91],[0,0]]`), wantErr: true},
+		{name: "longitude out of range", data: polygonJSON(`[[0,0],[181,0],[10,10],[0,0]]`), wantErr: true},
+		{name: "crosses the antimeridian", data: polygonJSON(`[[170,60],[-170,60],[-170,70],[170,70],[170,60]]`), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		geometry, err := Parse([]byte(tt.data))
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: Parse() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if err == nil && len(geometry.Polygons) != tt.polygons {
+			t.Errorf("%s: Parse() returned %d polygons, want %d", tt.name, len(geometry.Polygons), tt.polygons)
+		}
+	}
+}
+
+func TestContains(t *testing.T) {
+	tests := []struct {
+		name     string
+		geometry string
+		point    Point
+		want     bool
+	}{
+		{"inside", polygonJSON(square), Point{2, 3}, true},
+		{"outside", polygonJSON(square), Point{11, 5}, false},
+		{"left of the square", polygonJSON(square), Point{-0.001, 5}, false},
+		{"right of the square", polygonJSON(square), Point{10.000001, 5}, false},
+		{"ray along the bottom edge", polygonJSON(square), Point{-1, 0}, false},
+		{"ray along the top edge", polygonJSON(square), Point{-1, 10}, false},
+
+		{"left edge", polygonJSON(square), Point{0, 5}, true},
+		{"right edge", polygonJSON(square), Point{10, 5}, true},
+		{"bottom edge", polygonJSON(square), Point{5, 0}, true},
+		{"top edge", polygonJSON(square), Point{5, 10}, true},
+		{"bottom left vertex", polygonJSON(square), Point{0, 0}, true},
+		{"bottom right vertex", polygonJSON(square), Point{10, 0}, true},
+		{"top right vertex", polygonJSON(square), Point{10, 10}, true},
+		{"top left vertex", polygonJSON(square), Point{0, 10}, true},
+
+		{"in the hole", polygonJSON(square, squareHole), Point{5, 5}, false},
+		{"beside the hole", polygonJSON(square, squareHole), Point{2, 5}, true},
+		{"hole edge", polygonJSON(square, squareHole), Point{4, 5}, true},
+		{"hole vertex", polygonJSON(square, squareHole), Point{6, 6}, true},
+
+		{"in the notch", polygonJSON(notch), Point{5, 6}, false},
+		{"below the notch", polygonJSON(notch), Point{5, 4}, true},
+		{"ray through the notch vertex", polygonJSON(notch), Point{2, 5}, true},
+		{"notch vertex", polygonJSON(notch), Point{5, 5}, true},
+		{"notch edge", polygonJSON(notch), Point{7.5, 7.5}, true},
+
+		{"east of the antimeridian", `{"type":"MultiPolygon","coordinates":[[` + east + `],[` + west + `]]}`, Point{175, 65}, true},
+		{"west of the antimeridian", `{"type":"MultiPolygon","coordinates":[[` + east + `],[` + west + `]]}`, Point{-175, 65}, true},
+		{"on the antimeridian", `{"type":"MultiPolygon","coordinates":[[` + east + `],[` + west + `]]}`, Point{180, 65}, true},
+		{"on the antimeridian from the west", `{"type":"MultiPolygon","coordinates":[[` + east + `],[` + west + `]]}`, Point{-180, 65}, true},
+		{"far from the antimeridian", `{"type":"MultiPolygon","coordinates":[[` + east + `],[` + west + `]]}`, Point{0, 65}, false},
+	}
+
+	for _, tt := range tests {
+		geometry, err := Parse([]byte(tt.geometry))
+		if err != nil {
+			t.Fatalf("%s: Parse() error = %v", tt.name, err)
+		}
+		if got := geometry.Contains(tt.point); got != tt.want {
+			t.Errorf("%s: Contains(%v) = %v, want %v", tt.name, tt.point, got, tt.want)
+		}
+	}
+}
+
+func TestArea(t *testing.T) {
+	tests := []struct {
+		name     string
+		geometry string
+		want     float64
+	}{
+		{"square", polygonJSON(square), 100},
+		{"square with hole", polygonJSON(square, squareHole), 96},
+		{"notch", polygonJSON(notch), 75},
+		{"both halves", `{"type":"MultiPolygon","coordinates":[[` + east + `],[` + west + `]]}`, 200},
+	}
+
+	for _, tt := range tests {
+		geometry, err := Parse([]byte(tt.geometry))
+		if err != nil {
+			t.Fatalf("%s: Parse() error = %v", tt.name, err)
+		}
+		if got := geometry.Area(); got != tt.want {
+			t.Errorf("%s: Area() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		geometry string
+		wantType string
+	}{
+		{"polygon", polygonJSON(square, squareHole), "Polygon"},
+		{"multipolygon", `{"type":"MultiPolygon","coordinates":[[` + east + `],[` + west + `]]}`, "MultiPolygon"},
+	}
+
+	for _, tt := range tests {
+		geometry, err := Parse([]byte(tt.geometry))
+		if err != nil {
+			t.Fatalf("%s: Parse() error = %v", tt.name, err)
+		}
+		data, err := json.Marshal(geometry)
+		if err != nil {
+			t.Fatalf("%s: MarshalJSON() error = %v", tt.name, err)
+		}
+
+		var obj object
+		if err := json.Unmarshal(data, &obj); err != nil || obj.Type != tt.wantType {
+			t.Errorf("%s: MarshalJSON() = %s, want type %s", tt.name, data, tt.wantType)
+		}
+		again, err := Parse(data)
+		if err != nil || !reflect.DeepEqual(again, geometry) {
+			t.Errorf("%s: Parse(MarshalJSON()) = %v, %v, want %v", tt.name, again, err, geometry)
+		}
+	}
+}
+
+func TestWriteFeatureCollection(t *testing.T) {
+	geometry, err := Parse([]byte(polygonJSON(square)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	features := []Feature{
+		{ID: 1, Geometry: geometry, Properties: map[string]any{"name": "Алтай & Тыва"}},
+		{ID: 2, Properties: map[string]any{}},
+	}
+	if err := WriteFeatureCollection(&buf, features); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"type":"FeatureCollection","features":[` +
+		`{"type":"Feature","id":1,"geometry":{"type":"Polygon","coordinates":[` + square + `]},"properties":{"name":"Алтай & Тыва"}},` +
+		`{"type":"Feature","id":2,"geometry":null,"properties":{}}]}` + "\n"
+	if buf.String() != want {
+		t.Errorf("WriteFeatureCollection() = %s, want %s", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := WriteFeatureCollection(&buf, nil); err != nil || buf.String() != `{"type":"FeatureCollection","features":[]}`+"\n" {
+		t.Errorf("WriteFeatureCollection(nil) = %s, %v", buf.String(), err)
+	}
+}
@@ -0,0 +1,1141 @@
+package i18n
+
+// english translates messages into English
+var english = map[string]string{
+	// binomial/binomial.go
+	"род «%s» должен состоять только из латинских букв": "genus «%s» must consist of Latin letters only",
+	"не указан видовой эпитет (%s)":                     "the specific epithet is missing (%s)",
+	"не указан видовой эпитет: ожидается название вида из рода и эпитета, например «Matricaria chamomilla»": "the specific epithet is missing: a species name of a genus and an epithet is expected, e.g. «Matricaria chamomilla»",
+	"после ранга %s не указан эпитет":                 "no epithet after the rank %s",
+	"после знака гибрида %s не указан эпитет":         "no epithet after the hybrid sign %s",
+	"эпитет должен состоять только из латинских букв": "the epithet must consist of Latin letters only",
+
+	// dedup/dedup.go
+	"ID %d и ID %d: %s": "ID %d and ID %d: %s",
+	"одинаковое латинское название":                   "same latin name",
+	"одинаковое название":                             "same name",
+	"похожие латинские названия (отличие в %d симв.)": "similar latin names (%d chars differ)",
+
+	// geo/geo.go
+	"ошибка разбора GeoJSON: %v":                                                                     "error parsing GeoJSON: %v",
+	"в GeoJSON нет многоугольников":                                                                  "the GeoJSON has no polygons",
+	"неверные координаты Polygon: %v":                                                                "invalid Polygon coordinates: %v",
+	"неверные координаты MultiPolygon: %v":                                                           "invalid MultiPolygon coordinates: %v",
+	"неподдерживаемый тип GeoJSON: %s (доступно: Polygon, MultiPolygon, Feature, FeatureCollection)": "unsupported GeoJSON type: %s (available: Polygon, MultiPolygon, Feature, FeatureCollection)",
+	"у многоугольника нет внешнего контура":                                                          "the polygon has no outer ring",
+	"контур многоугольника должен содержать не менее 4 точек":                                        "a polygon ring must have at least 4 points",
+	"контур многоугольника должен быть замкнут":                                                      "a polygon ring must be closed",
+	"неверные координаты: %g, %g":                                                                    "invalid coordinates: %g, %g",
+	"контур пересекает антимеридиан между долготами %g и %g; разделите его на многоугольники по обе стороны от долготы 180": "a ring crosses the antimeridian between longitudes %g and %g; split it into polygons on either side of longitude 180",
+
+	// i18n/i18n.go
+	"неизвестный язык: %s (доступно: %s)": "unknown language: %s (available: %s)",
+
+	// models/calendar.go
+	"месяц должен быть от 1 до 12: %s": "month must be between 1 and 12: %s",
+	"неизвестный месяц: %s":            "unknown month: %s",
+	"янв":                              "Jan",
+	"фев":                              "Feb",
+	"мар":                              "Mar",
+	"апр":                              "Apr",
+	"май":                              "May",
+	"июн":                              "Jun",
+	"июл":                              "Jul",
+	"авг":                              "Aug",
+	"сен":                              "Sep",
+	"окт":                              "Oct",
+	"ноя":                              "Nov",
+	"дек":                              "Dec",
+
+	// models/dosage.go
+	"неизвестная единица измерения: %s (доступно: %s)": "unknown unit: %s (available: %s)",
+	"нельзя перевести %s в %s":                         "cannot convert %s to %s",
+	"мг":            "mg",
+	"г":             "g",
+	"кг":            "kg",
+	"мл":            "ml",
+	"л":             "l",
+	"ч. л.":         "tsp",
+	"ст. л.":        "tbsp",
+	"кап.":          "drops",
+	"шт.":           "pcs",
+	"настой":        "infusion",
+	"отвар":         "decoction",
+	"настойка":      "tincture",
+	"экстракт":      "extract",
+	"чай":           "tea",
+	"порошок":       "powder",
+	"свежий сок":    "fresh juice",
+	"масло":         "oil",
+	"мазь":          "ointment",
+	"сироп":         "syrup",
+	"компресс":      "compress",
+	"ванна":         "bath",
+	"в свежем виде": "fresh",
+	"неизвестная часть растения: %s (доступно: %s)":          "unknown plant part: %s (available: %s)",
+	"неизвестный способ приготовления: %s (доступно: %s)":    "unknown preparation method: %s (available: %s)",
+	"количество не может быть отрицательным":                 "the amount must not be negative",
+	"количество должно быть не меньше %s":                    "the amount must be at least %s",
+	"для количества нужно указать единицу измерения":         "an amount needs a unit",
+	"для единицы измерения нужно указать количество":         "a unit needs an amount",
+	"частота приема должна быть от 0 до %d раз в день":       "the frequency must be between 0 and %d times a day",
+	"продолжительность курса не может быть отрицательной":    "the course duration must not be negative",
+	"количество %s %s слишком мало, чтобы выразить его в %s": "the amount %s %s is too small to express in %s",
+	"×%d в день": "×%d a day",
+	"%d дн.":     "%d d",
+
+	// models/herb.go
+	"\nID: %d\nНазвание: %s\nЛатинское название: %s\nОписание: %s\nТоксичность: %s": "\nID: %d\nName: %s\nLatin name: %s\nDescription: %s\nToxicity: %s",
+	"\n  Ядовитые части: %s":                                       "\n  Toxic parts: %s",
+	"\n  Токсичные вещества: %s":                                   "\n  Toxic compounds: %s",
+	"\n  Симптомы отравления: %s":                                  "\n  Poisoning symptoms: %s",
+	"\nЦветение: %s":                                               "\nFlowering: %s",
+	"\nСбор: %s":                                                   "\nHarvest: %s",
+	"\nИзображение: %s\nСоздано: %s\nОбновлено: %s":                "\nImage: %s\nCreated: %s\nUpdated: %s",
+	"название травы не может быть пустым":                          "herb name cannot be empty",
+	"название травы должно содержать минимум %d символа":           "herb name must be at least %d characters long",
+	"название травы не должно превышать %d символов":               "herb name must not exceed %d characters",
+	"латинское название не должно превышать %d символов":           "latin name must not exceed %d characters",
+	"путь к изображению не должен превышать %d символов":           "image path must not exceed %d characters",
+	"имя автора записи не должно превышать %d символов":            "record author name must not exceed %d characters",
+	"имя автора изменений не должно превышать %d символов":         "change author name must not exceed %d characters",
+	"степень токсичности должна быть одной из: %s":                 "the toxicity level must be one of: %s",
+	"название токсичного вещества не должно превышать %d символов": "a toxic compound name must not exceed %d characters",
+	"описание симптома не должно превышать %d символов":            "a symptom description must not exceed %d characters",
+	"месяцы цветения должны быть от 1 до 12":                       "flowering months must be between 1 and 12",
+	"месяцы сбора должны быть от 1 до 12":                          "harvest months must be between 1 and 12",
+	"Название":           "Name",
+	"Латинское название": "Latin name",
+	"Токсичность":        "Toxicity",
+	"Создано":            "Created",
+	"Обновлено":          "Updated",
+	"Изменил":            "Updated by",
+	"ДА! ⚠️":             "YES! ⚠️",
+	"Нет":                "No",
+
+	// models/herb_name.go
+	"название не может быть пустым":                                           "the name must not be empty",
+	"код языка должен состоять из 2-3 латинских букв, например ru, en или la": "the language code must consist of 2-3 Latin letters, e.g. ru, en or la",
+	"тип названия должен быть одним из: %s":                                   "the name type must be one of: %s",
+	"народное":   "vernacular",
+	"синоним":    "synonym",
+	"принятое":   "accepted",
+	"русский":    "Russian",
+	"английский": "English",
+	"латынь":     "Latin",
+
+	// models/models.go
+	"название региона не может быть пустым":            "region name cannot be empty",
+	"название региона не должно превышать %d символов": "region name must not exceed %d characters",
+	"регион не может быть вложен сам в себя":           "a region cannot be nested in itself",
+
+	// models/safety.go
+	"неизвестная степень серьезности: %s (доступно: %s)": "unknown severity: %s (available: %s)",
+	"! незначительная": "! minor",
+	"!! умеренная":     "!! moderate",
+	"!!! серьезная":    "!!! major",
+	"степень серьезности должна быть одной из: %s":       "the severity must be one of: %s",
+	"состояние не может быть пустым":                     "the condition must not be empty",
+	"состояние не должно превышать %d символов":          "the condition must not exceed %d characters",
+	"название лекарства не может быть пустым":            "the drug name must not be empty",
+	"название лекарства не должно превышать %d символов": "the drug name must not exceed %d characters",
+
+	// models/source.go
+	"вид источника должен быть одним из: %s":                     "the source kind must be one of: %s",
+	"название источника не может быть пустым":                    "the source title must not be empty",
+	"название источника не должно превышать %d символов":         "the source title must not exceed %d characters",
+	"неверный год издания: %d":                                   "invalid publication year: %d",
+	"название журнала или сайта не должно превышать %d символов": "the journal or website name must not exceed %d characters",
+	"название издательства не должно превышать %d символов":      "the publisher name must not exceed %d characters",
+	"том не должен превышать %d символов":                        "the volume must not exceed %d characters",
+	"номер выпуска не должен превышать %d символов":              "the issue must not exceed %d characters",
+	"страницы не должны превышать %d символов":                   "pages must not exceed %d characters",
+	"неверный DOI: %s":                    "invalid DOI: %s",
+	"DOI не должен превышать %d символов": "the DOI must not exceed %d characters",
+	"неверный ISBN: %s":                   "invalid ISBN: %s",
+	"неверный URL: %s":                    "invalid URL: %s",
+	"URL не должен превышать %d символов": "the URL must not exceed %d characters",
+	"книга":  "book",
+	"статья": "article",
+	"сайт":   "website",
+	"другое": "other",
+	"сведения должны быть одними из: %s":                          "the information must be one of: %s",
+	"для ссылки на способ применения нужно указать его ID":        "a citation of a usage needs the usage ID",
+	"ID способа применения указывается только для сведений usage": "a usage ID is only given for usage information",
+	"описание":        "description",
+	"токсичность":     "toxicity",
+	"применение [%d]": "usage [%d]",
+	"применение":      "usage",
+
+	// models/taxon.go
+	"неизвестный ранг: %s (доступно: %s)": "unknown rank: %s (available: %s)",
+	"семейство": "family",
+	"род":       "genus",
+	"вид":       "species",
+	"название таксона не может быть пустым":            "the taxon name must not be empty",
+	"название таксона не должно превышать %d символов": "the taxon name must not exceed %d characters",
+
+	// models/toxicity.go
+	"неизвестная степень токсичности: %s (доступно: %s)": "unknown toxicity level: %s (available: %s)",
+	"нет":          "none",
+	"слабая":       "mild",
+	"умеренная":    "moderate",
+	"сильная":      "severe",
+	"смертельная":  "deadly",
+	"все растение": "whole plant",
+	"корень":       "root",
+	"корневище":    "rhizome",
+	"луковица":     "bulb",
+	"стебель":      "stem",
+	"кора":         "bark",
+	"лист":         "leaf",
+	"цветок":       "flower",
+	"плод":         "fruit",
+	"ягода":        "berry",
+	"семя":         "seed",
+	"сок":          "sap",
+
+	// repository/calendar.go
+	"ошибка получения календаря трав: %w":    "error getting herb calendar: %w",
+	"ошибка сканирования календаря трав: %w": "error scanning herb calendar: %w",
+	"ошибка итерации по календарю трав: %w":  "error iterating over herb calendar: %w",
+
+	// repository/errors.go
+	"такая трава уже существует":                                                       "such a herb already exists",
+	"%w: латинское название «%s» уже занято":                                           "%w: latin name «%s» is already taken",
+	"%w: название «%s» уже занято (укажите латинское название, чтобы различать травы)": "%w: name «%s» is already taken (give a latin name to tell the herbs apart)",
+
+	// repository/herb.go
+	"ошибка сканирования травы: %w":                          "failed to scan herb: %w",
+	"ошибка итерации по травам: %w":                          "failed to iterate over herbs: %w",
+	"ошибка создания травы: %w":                              "failed to create herb: %w",
+	"трава с ID %d не найдена":                               "herb with ID %d not found",
+	"ошибка получения травы: %w":                             "failed to get herb: %w",
+	"ошибка получения списка трав: %w":                       "failed to get herbs: %w",
+	"ошибка обновления травы: %w":                            "failed to update herb: %w",
+	"ошибка удаления травы: %w":                              "failed to delete herb: %w",
+	"ошибка получения количества затронутых строк: %w":       "failed to get the number of affected rows: %w",
+	"ошибка поиска трав: %w":                                 "failed to search herbs: %w",
+	"ошибка получения ядовитых трав: %w":                     "failed to get poisonous herbs: %w",
+	"ошибка удаления трав: %w":                               "failed to delete herbs: %w",
+	"удалено %d трав из %d: часть записей уже не существует": "deleted %d of %d herbs: some records no longer exist",
+	"ошибка переноса регионов: %w":                           "failed to move regions: %w",
+	"ошибка переноса способов применения: %w":                "failed to move usages: %w",
+	"ошибка переноса названий: %w":                           "failed to move names: %w",
+	"ошибка переноса похожих трав: %w":                       "failed to move look-alikes: %w",
+	"ошибка переноса противопоказаний: %w":                   "failed to move contraindications: %w",
+	"ошибка переноса взаимодействий: %w":                     "failed to move interactions: %w",
+	"ошибка переноса ссылок на источники: %w":                "failed to move citations: %w",
+
+	// repository/herb_name.go
+	"у травы уже есть название «%s» (%s)": "the herb already has the name «%s» (%s)",
+	"ошибка добавления названия: %w":      "failed to add name: %w",
+	"ошибка получения названий травы: %w": "failed to get herb names: %w",
+	"ошибка сканирования названия: %w":    "failed to scan name: %w",
+	"ошибка итерации по названиям: %w":    "failed to iterate over names: %w",
+	"ошибка удаления названия: %w":        "failed to delete name: %w",
+	"название с ID %d не найдено":         "name with ID %d not found",
+
+	// repository/lookalike.go
+	"трава не может быть похожа сама на себя":    "a herb cannot be a look-alike of itself",
+	"ошибка сохранения похожей травы: %w":        "failed to save look-alike: %w",
+	"ошибка удаления похожей травы: %w":          "failed to delete look-alike: %w",
+	"травы с ID %d и %d не отмечены как похожие": "herbs with ID %d and %d are not marked as look-alikes",
+	"ошибка получения похожих трав: %w":          "failed to get look-alikes: %w",
+	"ошибка сканирования похожей травы: %w":      "failed to scan look-alike: %w",
+	"ошибка итерации по похожим травам: %w":      "failed to iterate over look-alikes: %w",
+
+	// repository/region.go
+	"ошибка получения списка регионов: %w":                    "failed to get regions: %w",
+	"ошибка сканирования региона: %w":                         "failed to scan region: %w",
+	"ошибка итерации по регионам: %w":                         "failed to iterate over regions: %w",
+	"регион с ID %d не найден":                                "region with ID %d not found",
+	"ошибка получения региона: %w":                            "error getting region: %w",
+	"ошибка получения подрегионов: %w":                        "error getting sub-regions: %w",
+	"ошибка получения границ регионов: %w":                    "error getting region boundaries: %w",
+	"ошибка сохранения границы региона: %w":                   "error saving region boundary: %w",
+	"ошибка получения трав регионов: %w":                      "error getting herbs of regions: %w",
+	"ошибка итерации по травам регионов: %w":                  "error iterating over herbs of regions: %w",
+	"ошибка создания региона: %w":                             "error creating region: %w",
+	"регион нельзя вложить в него самого или в его подрегион": "a region cannot be moved into itself or into its sub-region",
+	"ошибка изменения родительского региона: %w":              "error changing parent region: %w",
+	"ошибка получения регионов травы: %w":                     "failed to get herb regions: %w",
+	"ошибка привязки травы к региону: %w":                     "failed to link herb to region: %w",
+	"регион «%s» не найден":                                   "region «%s» not found",
+	"найдено несколько регионов с названием «%s»":             "several regions are named «%s»",
+	"месяцы должны быть от 1 до 12":                           "months must be between 1 and 12",
+
+	// repository/safety.go
+	"ошибка удаления записи: %w":                       "failed to delete record: %w",
+	"противопоказание «%s» для этой травы уже указано": "contraindication «%s» is already recorded for this herb",
+	"ошибка создания противопоказания: %w":             "failed to create contraindication: %w",
+	"противопоказание с ID %d не найдено":              "contraindication with ID %d not found",
+	"ошибка получения противопоказаний: %w":            "failed to get contraindications: %w",
+	"ошибка сканирования противопоказания: %w":         "failed to scan contraindication: %w",
+	"ошибка итерации по противопоказаниям: %w":         "failed to iterate over contraindications: %w",
+	"взаимодействие с «%s» для этой травы уже указано": "the interaction with «%s» is already recorded for this herb",
+	"ошибка создания взаимодействия: %w":               "failed to create interaction: %w",
+	"взаимодействие с ID %d не найдено":                "interaction with ID %d not found",
+	"ошибка получения взаимодействий: %w":              "failed to get interactions: %w",
+	"ошибка сканирования взаимодействия: %w":           "failed to scan interaction: %w",
+	"ошибка итерации по взаимодействиям: %w":           "failed to iterate over interactions: %w",
+
+	// repository/source.go
+	"источник с DOI %s уже есть":                  "a source with DOI %s already exists",
+	"источник с ISBN %s уже есть":                 "a source with ISBN %s already exists",
+	"ошибка создания источника: %w":               "failed to create source: %w",
+	"источник с ID %d не найден":                  "source with ID %d not found",
+	"ошибка получения источника: %w":              "failed to get source: %w",
+	"ошибка получения источников: %w":             "failed to get sources: %w",
+	"ошибка сканирования источника: %w":           "failed to scan source: %w",
+	"ошибка итерации по источникам: %w":           "failed to iterate over sources: %w",
+	"у травы нет способа применения с ID %d":      "the herb has no usage with ID %d",
+	"ошибка получения способа применения: %w":     "failed to get usage: %w",
+	"этот источник уже указан для этих сведений":  "this source is already cited for this information",
+	"ошибка добавления ссылки на источник: %w":    "failed to add citation: %w",
+	"ссылка на источник с ID %d не найдена":       "citation with ID %d not found",
+	"ошибка получения ссылок на источники: %w":    "failed to get citations: %w",
+	"ошибка сканирования ссылки на источник: %w":  "failed to scan citation: %w",
+	"ошибка итерации по ссылкам на источники: %w": "failed to iterate over citations: %w",
+
+	// repository/taxon.go
+	"ошибка получения таксонов: %w":       "failed to get taxa: %w",
+	"ошибка сканирования таксона: %w":     "failed to scan taxon: %w",
+	"ошибка итерации по таксонам: %w":     "failed to iterate over taxa: %w",
+	"не найден таксон «%s» (%s)":          "taxon «%s» (%s) not found",
+	"ошибка получения таксона: %w":        "failed to get taxon: %w",
+	"ошибка сохранения таксона: %w":       "failed to save taxon: %w",
+	"ошибка привязки травы к таксону: %w": "failed to link herb to taxon: %w",
+
+	// repository/unit_of_work.go
+	"транзакция не выполнена после %d попыток: %w": "transaction failed after %d attempts: %w",
+	"ошибка начала транзакции: %w":                 "failed to begin transaction: %w",
+	"ошибка фиксации транзакции: %w":               "failed to commit transaction: %w",
+
+	// repository/usage.go
+	"ошибка получения типов использования: %w":   "failed to get usage types: %w",
+	"ошибка сканирования типа использования: %w": "failed to scan usage type: %w",
+	"ошибка создания способа применения: %w":     "failed to create usage: %w",
+	"способ применения с ID %d не найден":        "usage with ID %d not found",
+	"ошибка получения способов применения: %w":   "failed to get usages: %w",
+	"ошибка сканирования способа применения: %w": "failed to scan usage: %w",
+
+	// table/table.go
+	"неизвестный стиль таблицы: %s (доступно: %s)": "unknown table style: %s (available: %s)",
+
+	// tui/screen.go
+	"не удалось перевести терминал в полноэкранный режим: %v": "failed to switch the terminal to full-screen mode: %v",
+
+	// cmd/apply.go
+	"Применить описание трав из YAML-файла": "Apply herbs from a YAML file",
+	`Приводит базу данных в соответствие с YAML-файлом: создает отсутствующие травы
+и обновляет измененные. Травы сопоставляются по латинскому названию
+(или по названию, если латинское не указано) без учета регистра и лишних пробелов.
+Поля, отсутствующие в файле, не изменяются. Перед применением выводится план изменений.
+
+Формат файла:
+  herbs:
+    - name: Ромашка
+      latin_name: Matricaria chamomilla
+      description: Противовоспалительное средство
+      toxicity: none
+      flowering: 6-8
+      harvest: 6-8
+    - name: Белена
+      latin_name: Hyoscyamus niger
+      toxicity: severe
+      toxic_parts: [whole]
+      toxic_compounds: [атропин, скополамин]
+
+Поле is_poisonous из старых файлов по-прежнему принимается: true означает
+toxicity: severe, если степень не указана явно.`: `Brings the database in line with a YAML file: creates missing herbs
+and updates changed ones. Herbs are matched by latin name
+(or by name when there is no latin name), ignoring case and extra spaces.
+Fields missing from the file are left unchanged. A plan is shown before applying.
+
+File format:
+  herbs:
+    - name: Chamomile
+      latin_name: Matricaria chamomilla
+      description: Anti-inflammatory
+      toxicity: none
+      flowering: 6-8
+      harvest: 6-8
+    - name: Henbane
+      latin_name: Hyoscyamus niger
+      toxicity: severe
+      toxic_parts: [whole]
+      toxic_compounds: [atropine, scopolamine]
+
+The is_poisonous field of older files is still accepted: true means
+toxicity: severe unless the level is given explicitly.`,
+	"YAML-файл с травами (- для чтения из stdin)":      "YAML file with herbs (- to read from stdin)",
+	"только показать план изменений":                   "only show the plan",
+	"применить без подтверждения":                      "apply without confirmation",
+	"❌ нет соединения с БД (database.GetDB() == nil)":  "❌ no database connection (database.GetDB() == nil)",
+	"не удалось получить список трав: %v":              "failed to get the list of herbs: %v",
+	"Изменений нет.":                                   "No changes.",
+	"Применить изменения?":                             "Apply the changes?",
+	"Применение отменено.":                             "Apply cancelled.",
+	"не удалось создать траву «%s»: %w":                "failed to create herb «%s»: %w",
+	"не удалось обновить траву с ID %d: %w":            "failed to update herb with ID %d: %w",
+	"✅ Применено: создано %d, обновлено %d\n":          "✅ Applied: %d created, %d updated\n",
+	"не удалось прочитать файл: %v":                    "failed to read the file: %v",
+	"ошибка разбора YAML: %v":                          "YAML parse error: %v",
+	"в файле %s нет описаний трав":                     "file %s contains no herbs",
+	"запись #%d дублирует запись #%d (%s)":             "entry #%d duplicates entry #%d (%s)",
+	"запись #%d (%s): %v":                              "entry #%d (%s): %v",
+	"План изменений:":                                  "Plan:",
+	"  + создать    %s\n":                              "  + create     %s\n",
+	"  ~ обновить   ID %d %s\n":                        "  ~ update     ID %d %s\n",
+	"  = без изменений ID %d %s\n":                     "  = unchanged  ID %d %s\n",
+	"\nСоздать: %d, обновить: %d, без изменений: %d\n": "\nTo create: %d, to update: %d, unchanged: %d\n",
+
+	// cmd/args.go
+	"неверный ID: %s":                                       "invalid ID: %s",
+	"неверный диапазон ID: %s":                              "invalid ID range: %s",
+	"слишком большой диапазон ID: %s (не более %d)":         "ID range too large: %s (at most %d)",
+	"неверная дата: %s (ожидается ГГГГ-ММ-ДД или RFC 3339)": "invalid date: %s (expected YYYY-MM-DD or RFC 3339)",
+
+	// cmd/calendar.go
+	"Календарь цветения и сбора трав": "Flowering and harvest calendar of herbs",
+	`Без аргументов выводит травы, которые можно собирать в месяце --month
+(по умолчанию в текущем), а с --flowering - травы, которые в этом месяце цветут.
+С --region выводятся только травы, растущие в регионе или в его подрегионах,
+и учитываются сроки цветения и сбора, указанные для этих регионов командой
+herb season.
+
+С аргументами (ID или названия трав) выводит таблицу на 12 месяцев:
+Ц - цветение, С - сбор.`: `Without arguments lists the herbs that can be harvested in month --month
+(the current one by default), or with --flowering the herbs that flower in that month.
+With --region only the herbs growing in the region or its sub-regions are listed,
+and the flowering and harvest months set for those regions with herb season
+are taken into account.
+
+With arguments (herb IDs or names) prints a 12-month table:
+F - flowering, H - harvest.`,
+	"Указать сроки цветения и сбора травы в регионе": "Set the flowering and harvest months of a herb in a region",
+	`Привязывает траву к региону и задает сроки цветения и сбора в этом регионе.
+Если флаг не указан, сохраняются сроки, уже заданные для региона, а если
+их нет - действуют сроки, указанные для самой травы. Значение inherit удаляет
+сроки, заданные для региона, и для него снова действуют сроки травы.`: `Links a herb to a region and sets its flowering and harvest months in that region.
+When a flag is not given, the months already set for the region are kept;
+if there are none, the months of the herb itself apply. The value inherit removes
+the months set for the region, so that the months of the herb apply again.`,
+	"месяц: номер или название (по умолчанию текущий)":   "month: number or name (the current one by default)",
+	"регион (ID или название) вместе с его подрегионами": "region (ID or name) together with its sub-regions",
+	"показать цветущие травы вместо трав для сбора":      "show flowering herbs instead of herbs to harvest",
+	"формат вывода (text, json)":                         "output format (text, json)",
+	"Ц":                                                  "F",
+	"С":                                                  "H",
+	"неизвестный формат вывода: %s (доступно: text, json)": "unknown output format: %s (available: text, json)",
+	"Цветущие травы не найдены (%s)\n":                     "No flowering herbs found (%s)\n",
+	"Травы для сбора не найдены (%s)\n":                    "No herbs to harvest found (%s)\n",
+	"Цветут (%s):\n":           "Flowering (%s):\n",
+	"Можно собирать (%s):\n":   "Can be harvested (%s):\n",
+	"Травы не найдены":         "No herbs found",
+	"Трава":                    "Herb",
+	"Регион":                   "Region",
+	"\nЦ - цветение, С - сбор": "\nF - flowering, H - harvest",
+	"✅ Сроки травы «%s» в регионе «%s» сохранены\n": "✅ Months of herb «%s» in region «%s» saved\n",
+	"  Цветение: %s\n  Сбор: %s\n":                  "  Flowering: %s\n  Harvest: %s\n",
+
+	// cmd/check.go
+	"Проверить безопасность сочетания трав": "Check the safety of a combination of herbs",
+	`Проверяет травы (по ID или названию) и сообщает обо всех найденных проблемах,
+начиная с самых серьезных: ядовитость трав, противопоказания и взаимодействия
+с лекарствами. Слабо токсичные травы отмечаются как незначительная проблема.
+
+С --condition учитываются только противопоказания при указанных состояниях,
+с --drug - только взаимодействия с указанными лекарствами или группами лекарств;
+без этих флагов выводятся все противопоказания и взаимодействия трав.
+Состояние или лекарство совпадает с записью в базе, если одно из названий
+содержит другое (без учета регистра). Состояния и лекарства, о которых в базе
+нет ни одной записи, перечисляются отдельно как непроверенные.`: `Checks herbs (by ID or name) and reports every problem found, the most serious
+first: poisonous herbs, contraindications and interactions
+with drugs. Mildly toxic herbs are reported as a minor problem.
+
+With --condition only the contraindications for the given conditions are reported,
+with --drug only the interactions with the given drugs or drug classes;
+without these flags all contraindications and interactions of the herbs are shown.
+A condition or drug matches a stored record if either name contains
+the other (case-insensitive). Conditions and drugs the database has no
+records about are listed separately as unchecked.`,
+	"состояния через запятую: беременность, дети, заболевания":    "comma-separated conditions: pregnancy, children, diseases",
+	"лекарства или группы лекарств через запятую":                 "comma-separated drugs or drug classes",
+	"трава «%s» не найдена":                                       "herb «%s» not found",
+	"под «%s» подходит несколько трав: %s; укажите ID":            "several herbs match «%s»: %s; give an ID",
+	"ядовита, токсичность: %s":                                    "poisonous, toxicity: %s",
+	"слабо токсична: %s":                                          "mildly toxic: %s",
+	"противопоказание: %s":                                        "contraindication: %s",
+	"взаимодействие с «%s»":                                       "interaction with «%s»",
+	"Травы: %s\n":                                                 "Herbs: %s\n",
+	"Состояния: %s\n":                                             "Conditions: %s\n",
+	"Лекарства: %s\n":                                             "Drugs: %s\n",
+	"❓ Нет сведений о противопоказаниях при: %s - не проверено\n": "❓ No contraindication data for: %s - not checked\n",
+	"❓ Нет сведений о взаимодействиях с: %s - не проверено\n":     "❓ No interaction data for: %s - not checked\n",
+	"Известных проблем не найдено, но не все состояния и лекарства удалось проверить": "No known problems found, but not all conditions and drugs could be checked",
+	"✅ Известных проблем не найдено":                    "✅ No known problems found",
+	"\n⚠️  Найдено проблем: %d, из них серьезных: %d\n": "\n⚠️  Problems found: %d, major: %d\n",
+
+	// cmd/columns.go
+	"Род":         "Genus",
+	"Вид":         "Species",
+	"Описание":    "Description",
+	"Ядовито":     "Poisonous",
+	"Цветение":    "Flowering",
+	"Сбор":        "Harvest",
+	"Изображение": "Image",
+	"Создал":      "Created by",
+	"столбцы таблицы через запятую: id, name, latin, genus, species, desc, poisonous, toxicity, flowering, harvest, image, created, updated, created_by, updated_by": "comma-separated table columns: id, name, latin, genus, species, desc, poisonous, toxicity, flowering, harvest, image, created, updated, created_by, updated_by",
+	"стиль таблицы: plain, markdown или box": "table style: plain, markdown or box",
+	"неизвестный столбец: %s (доступно: %s)": "unknown column: %s (available: %s)",
+	"не выбрано ни одного столбца":           "no columns selected",
+
+	// cmd/edit.go
+	"Редактировать траву в текстовом редакторе": "Edit a herb in a text editor",
+	`Открывает траву с указанным ID в виде YAML в редакторе из $VISUAL или $EDITOR
+(по умолчанию vi). После сохранения запись проверяется; если в ней есть ошибки,
+редактор открывается снова с описанием ошибок в комментариях. Перед сохранением
+в базу данных показываются изменения.
+
+Чтобы отменить редактирование, сохраните пустой файл.`: `Opens the herb with the given ID as YAML in the editor from $VISUAL or $EDITOR
+(vi by default). The record is validated after saving; if it has errors,
+the editor is opened again with the errors in comments. The changes are shown
+before they are saved to the database.
+
+To cancel editing, save an empty file.`,
+	"# ОШИБКА: ": "# ERROR: ",
+	"сохранить без подтверждения":                                                   "save without confirmation",
+	"для редактирования нужен терминал":                                             "editing requires a terminal",
+	"Редактирование отменено.":                                                      "Edit cancelled.",
+	"Изменения в траве ID %d:\n":                                                    "Changes to herb ID %d:\n",
+	"Сохранить изменения?":                                                          "Save the changes?",
+	"не удалось обновить траву: %w":                                                 "failed to update the herb: %w",
+	"✅ Трава с ID %d успешно обновлена\n":                                           "✅ Herb with ID %d updated\n",
+	"не удалось создать временный файл: %v":                                         "failed to create a temporary file: %v",
+	"не удалось записать временный файл: %v":                                        "failed to write the temporary file: %v",
+	"не удалось прочитать временный файл: %v":                                       "failed to read the temporary file: %v",
+	"ошибка формирования YAML: %v":                                                  "failed to build YAML: %v",
+	"# Редактирование травы ID %d.\n":                                               "# Editing herb ID %d.\n",
+	"# Строки, начинающиеся с #, игнорируются. Сохраните пустой файл для отмены.\n": "# Lines starting with # are ignored. Save an empty file to cancel.\n",
+	"ошибка запуска редактора %s: %v":                                               "failed to run editor %s: %v",
+
+	// cmd/geo.go
+	"Задать границу региона в GeoJSON": "Set the boundary of a region in GeoJSON",
+	`Сохраняет границу региона (ID или название) из GeoJSON-файла (- для чтения
+из stdin). Принимаются Polygon и MultiPolygon, а также Feature и FeatureCollection
+с такими геометриями; многоугольники всех объектов объединяются.
+Координаты указываются в порядке GeoJSON: долгота, затем широта.
+Границу, пересекающую антимеридиан (долготу 180), нужно разделить на
+многоугольники по обе стороны от него.
+
+С --clear граница удаляется.`: `Stores the boundary of a region (ID or name) from a GeoJSON file (- to read
+from stdin). Polygon and MultiPolygon are accepted, as well as Feature and
+FeatureCollection with such geometries; the polygons of all features are joined.
+Coordinates are given in GeoJSON order: longitude, then latitude.
+A boundary that crosses the antimeridian (longitude 180) must be split
+into polygons on either side of it.
+
+With --clear the boundary is removed.`,
+	"Экспортировать регионы с травами в GeoJSON": "Export regions with their herbs to GeoJSON",
+	`Выводит регионы, у которых задана граница, в виде GeoJSON FeatureCollection
+для картографических программ. В свойствах каждого объекта указываются название,
+описание и родительский регион, а также травы, привязанные к региону.`: `Prints the regions that have a boundary as a GeoJSON FeatureCollection
+for mapping tools. The properties of each feature hold the name, description
+and parent region, as well as the herbs linked to the region.`,
+	"Найти травы, растущие в точке": "Find the herbs growing at a point",
+	`Находит регионы, граница которых содержит точку с координатами --lat и --lon,
+и выводит травы, привязанные к этим регионам. Регионы перечисляются
+от меньшего к большему. Регионы без границы не учитываются.`: `Finds the regions whose boundary contains the point with coordinates --lat and --lon,
+and lists the herbs linked to those regions. Regions are listed
+from the smallest to the largest. Regions without a boundary are not considered.`,
+	"удалить границу региона":                                                        "remove the boundary of the region",
+	"широта в градусах":                                                              "latitude in degrees",
+	"долгота в градусах":                                                             "longitude in degrees",
+	"граница региона «%s»: %w":                                                       "boundary of region «%s»: %w",
+	"укажите файл с границей или флаг --clear":                                       "specify a boundary file or the --clear flag",
+	"✅ Граница региона «%s» удалена\n":                                               "✅ Boundary of region «%s» removed\n",
+	"ошибка формирования GeoJSON: %v":                                                "error building GeoJSON: %v",
+	"✅ Граница региона «%s» сохранена (многоугольников: %d)\n":                       "✅ Boundary of region «%s» saved (polygons: %d)\n",
+	"неверные координаты: широта должна быть от -90 до 90, долгота - от -180 до 180": "invalid coordinates: latitude must be between -90 and 90, longitude between -180 and 180",
+	"Точка %g, %g не входит ни в один регион с заданной границей\n":                  "Point %g, %g is not inside any region with a boundary\n",
+	"Регионы: %s\n": "Regions: %s\n",
+	"Травы в этих регионах не найдены": "No herbs found in these regions",
+	"Найдено трав: %d\n\n":             "Herbs found: %d\n\n",
+
+	// cmd/herb.go
+	"Управление травами": "Manage herbs",
+	"Команды для работы с записями о лекарственных травах в базе данных.": "Commands for working with medicinal herb records in the database.",
+	"Создать новую траву": "Create a new herb",
+	`Создает новую запись о лекарственной траве в базе данных.
+Если команда запущена в терминале без флагов, значения полей запрашиваются
+по очереди, с возможностью выбрать регионы и типы использования.`: `Creates a new medicinal herb record in the database.
+When run in a terminal without flags, the fields are asked for
+one by one, with a choice of regions and usage types.`,
+	"Показать все травы": "List all herbs",
+	`Выводит список всех лекарственных трав из базы данных.
+
+В табличном формате (--table) можно выбрать столбцы с помощью --columns
+и стиль оформления с помощью --style: plain (без рамок), markdown или box.
+Таблица сужается по ширине терминала.
+
+Флаг --genus оставляет только травы указанного рода (без учета регистра),
+флаг --family - травы всех родов и видов семейства (см. taxonomy tree),
+флаг --region - травы, растущие в регионе или в любом из его подрегионов
+(см. region tree).
+
+Флаг --format задает шаблон Go text/template, который выполняется для каждой
+травы (так же работает в get, search и poisonous). В шаблоне доступны поля
+травы (.ID, .Name, .LatinName, .Genus, .Species, .InfraRank, .InfraEpithet,
+.Authorship, .Description, .IsPoisonous, .Toxicity, .ToxicParts, .ToxicCompounds,
+.ToxicitySymptoms, .ImagePath, .CreatedAt, .UpdatedAt, .CreatedBy, .UpdatedBy),
+сама трава .Herb, а также ее регионы .Regions, способы применения .Usages
+и другие названия .Names, которые загружаются, только если шаблон к ним обращается.
+Функции: truncate N, upper, lower, date "2006-01-02", join SEP, pluck "Поле".
+Вместо шаблона можно указать имя файла NAME.tmpl из каталога шаблонов
+(templates_dir в конфигурации, по умолчанию ~/.config/herbs-cli/templates).`: `Lists all medicinal herbs in the database.
+
+In table format (--table) the columns can be chosen with --columns
+and the look with --style: plain (no borders), markdown or box.
+The table is narrowed to the terminal width.
+
+The --genus flag keeps only the herbs of the given genus (case-insensitive),
+the --family flag the herbs of all genera and species of a family (see taxonomy tree),
+the --region flag the herbs growing in a region or any of its sub-regions
+(see region tree).
+
+The --format flag takes a Go text/template that is executed for each
+herb (it works the same in get, search and poisonous). The template can use
+the herb fields (.ID, .Name, .LatinName, .Genus, .Species, .InfraRank,
+.InfraEpithet, .Authorship, .Description, .IsPoisonous, .Toxicity, .ToxicParts,
+.ToxicCompounds, .ToxicitySymptoms, .ImagePath, .CreatedAt, .UpdatedAt,
+.CreatedBy, .UpdatedBy), the herb itself as .Herb, and its regions .Regions,
+usages .Usages and alternative names .Names, which are loaded only when the
+template refers to them.
+Functions: truncate N, upper, lower, date "2006-01-02", join SEP, pluck "Field".
+Instead of a template, the name of a NAME.tmpl file from the templates directory
+can be given (templates_dir in the config, default ~/.config/herbs-cli/templates).`,
+	"Получить траву по ID": "Get a herb by ID",
+	`Выводит подробную информацию о траве с указанным ID. Если неядовитую траву
+легко спутать с ядовитой (см. herb lookalikes), выводится предупреждение.
+С --sources выводятся источники сведений о траве (см. herb cite).`: `Shows detailed information about the herb with the given ID. If a non-poisonous herb
+is easily confused with a poisonous one (see herb lookalikes), a warning is shown.
+With --sources the sources of the information about the herb are shown (see herb cite).`,
+	"Обновить траву": "Update a herb",
+	"Обновляет информацию о траве с указанным ID.": "Updates the herb with the given ID.",
+	"Удалить травы": "Delete herbs",
+	`Удаляет травы с указанными ID или подходящие под фильтр из базы данных.
+ID можно перечислять через пробел или запятую и задавать диапазонами (5-9).
+Перед удалением показывается список удаляемых трав и запрашивается подтверждение;
+все травы удаляются в одной транзакции.
+
+Условия --where:
+  poisonous      - только ядовитые травы
+  not-poisonous  - только неядовитые травы`: `Deletes the herbs with the given IDs or matching a filter from the database.
+IDs can be separated by spaces or commas and given as ranges (5-9).
+The herbs to delete are listed and a confirmation is asked for first;
+all herbs are deleted in a single transaction.
+
+--where conditions:
+  poisonous      - only poisonous herbs
+  not-poisonous  - only non-poisonous herbs`,
+	"Найти травы по названию": "Find herbs by name",
+	`Выполняет поиск трав по названию, латинскому названию и другим названиям
+(народным и синонимам) с поддержкой частичного совпадения.`: `Searches herbs by name, latin name and alternative names
+(vernacular names and synonyms); partial matches are supported.`,
+	"Показать ядовитые травы": "List poisonous herbs",
+	`Выводит список ядовитых трав из базы данных, начиная с самых опасных.
+
+По умолчанию показываются травы со степенью токсичности moderate и выше;
+--min-level задает другую минимальную степень: mild, moderate, severe или deadly.
+В таблице степень отмечается знаками: ! слабая, !! умеренная, !!! сильная,
+☠️ !!!! смертельная.`: `Lists the poisonous herbs in the database, the most dangerous first.
+
+By default herbs with toxicity moderate and above are shown;
+--min-level sets another minimum level: mild, moderate, severe or deadly.
+In tables the level is marked with: ! mild, !! moderate, !!! severe,
+☠️ !!!! deadly.`,
+	"название травы (обязательно без интерактивного режима)": "herb name (required when not interactive)",
+	"латинское название": "latin name",
+	"описание травы":     "herb description",
+	"является ли трава ядовитой (то же, что --toxicity severe)":          "whether the herb is poisonous (same as --toxicity severe)",
+	"путь к изображению":                                                 "image path",
+	"новое название травы":                                               "new herb name",
+	"новое латинское название":                                           "new latin name",
+	"новое описание травы":                                               "new herb description",
+	"новый путь к изображению":                                           "new image path",
+	"удалить без подтверждения":                                          "delete without confirmation",
+	"то же, что --yes":                                                   "same as --yes",
+	"условия отбора: poisonous, not-poisonous":                           "filter conditions: poisonous, not-poisonous",
+	"удалить травы, созданные раньше даты (ГГГГ-ММ-ДД)":                  "delete herbs created before the date (YYYY-MM-DD)",
+	"удалить травы, созданные не раньше даты (ГГГГ-ММ-ДД)":               "delete herbs created on or after the date (YYYY-MM-DD)",
+	"показать источники сведений о траве":                                "show the sources of information about the herb",
+	"вывод в табличном формате":                                          "print as a table",
+	"показать только травы указанного рода":                              "show only the herbs of the given genus",
+	"показать только травы указанного семейства":                         "show only the herbs of the given family",
+	"показать только травы региона и его подрегионов (ID или название)":  "show only the herbs of a region and its sub-regions (ID or name)",
+	"минимальная степень токсичности: mild, moderate, severe или deadly": "minimum toxicity level: mild, moderate, severe or deadly",
+	"флаг --name обязателен, если ввод не является терминалом":           "the --name flag is required when input is not a terminal",
+	"не удалось создать траву: %w":                                       "failed to create the herb: %w",
+	"✅ Трава успешно создана с ID: %d\n":                                 "✅ Herb created with ID: %d\n",
+	"База данных пуста. Добавьте травы с помощью команды 'create'.":      "The database is empty. Add herbs with the 'create' command.",
+	"травы с ID %s не найдены":                                           "herbs with ID %s not found",
+	"Нет трав, подходящих под условия.":                                  "No herbs match the conditions.",
+	"Будет удалено трав: %d\n\n":                                         "Herbs to delete: %d\n\n",
+	"Вы уверены?":                  "Are you sure?",
+	"Удаление отменено.":           "Deletion cancelled.",
+	"не удалось удалить травы: %v": "failed to delete herbs: %v",
+	"✅ Удалено трав: %d (ID %s)\n": "✅ Herbs deleted: %d (ID %s)\n",
+	"неизвестное условие --where: %s (доступно: poisonous, not-poisonous)":            "unknown --where condition: %s (available: poisonous, not-poisonous)",
+	"укажите либо ID, либо условия отбора, но не то и другое вместе":                  "give either IDs or filter conditions, not both",
+	"укажите ID трав или условия отбора (--where, --created-before, --created-after)": "give herb IDs or filter conditions (--where, --created-before, --created-after)",
+	"ошибка поиска: %v":                            "search failed: %v",
+	"Травы с названием '%s' не найдены.\n":         "No herbs named '%s' found.\n",
+	"Найдено трав по запросу '%s': %d\n\n":         "Herbs found for '%s': %d\n\n",
+	"не удалось получить список ядовитых трав: %v": "failed to get poisonous herbs: %v",
+	"В базе данных нет записей о ядовитых травах.": "There are no poisonous herbs in the database.",
+	"⚠️  Найдено ядовитых трав: %d\n\n":            "⚠️  Poisonous herbs found: %d\n\n",
+	"⚠️  латинское название «%s»: %s\n":            "⚠️  latin name «%s»: %s\n",
+
+	// cmd/lookalikes.go
+	"Показать травы, с которыми легко спутать траву": "Show the herbs a herb is easily confused with",
+	`Выводит травы, внешне похожие на траву с указанным ID, начиная с самых
+ядовитых, и признаки, по которым их можно различить.`: `Lists the herbs that look like the herb with the given ID, the most
+poisonous first, and the features that tell them apart.`,
+	"Отметить травы как похожие": "Mark herbs as look-alikes",
+	`Отмечает две травы как внешне похожие. Связь симметрична; повторный вызов
+заменяет описание отличий.`: `Marks two herbs as looking alike. The relation is symmetric; calling it again
+replaces the distinguishing notes.`,
+	"Убрать отметку о сходстве трав":                                 "Remove the look-alike mark",
+	"как отличить травы друг от друга":                               "how to tell the herbs apart",
+	"⚠️  ОСТОРОЖНО: эту траву легко спутать с ядовитыми растениями!": "⚠️  CAUTION: this herb is easily confused with poisonous plants!",
+	"  • %s (ID %d) — токсичность: %s\n":                             "  • %s (ID %d) — toxicity: %s\n",
+	"    Как отличить: %s\n":                                         "    How to tell apart: %s\n",
+	"Для травы «%s» похожие травы не указаны.\n":                     "No look-alikes are recorded for herb «%s».\n",
+	"Травы, похожие на «%s»:\n":                                      "Herbs that look like «%s»:\n",
+	"✅ Травы «%s» и «%s» отмечены как похожие\n":                     "✅ Herbs «%s» and «%s» marked as look-alikes\n",
+	"✅ Отметка о сходстве трав с ID %d и %d удалена\n":               "✅ Look-alike mark of herbs with ID %d and %d removed\n",
+
+	// cmd/merge.go
+	"Найти возможные дубликаты трав": "Find possible duplicate herbs",
+	`Группирует травы, которые похожи на дубликаты: с одинаковым названием или
+латинским названием (без учета регистра и пробелов), а также с латинскими
+названиями, отличающимися опечаткой.`: `Groups herbs that look like duplicates: with the same name or
+latin name (ignoring case and spaces), or with latin names
+that differ by a typo.`,
+	"Объединить две записи о траве": "Merge two herb records",
+	`Объединяет траву DROP_ID с травой KEEP_ID: регионы и способы применения
+переносятся на KEEP_ID, после чего запись DROP_ID удаляется. Все изменения
+выполняются в одной транзакции.
+
+Пустые поля KEEP_ID заполняются значениями из DROP_ID. Трава считается ядовитой,
+если ядовитой отмечена хотя бы одна из записей. Остальные расхождения решаются
+интерактивно или правилом --prefer:
+  keep     - оставить значение KEEP_ID
+  drop     - взять значение DROP_ID
+  longest  - взять более длинное значение`: `Merges herb DROP_ID into herb KEEP_ID: regions and usages are moved
+to KEEP_ID, then the DROP_ID record is deleted. All changes
+are made in a single transaction.
+
+Empty fields of KEEP_ID are filled from DROP_ID. The herb is poisonous
+if either record is marked poisonous. Other differences are resolved
+interactively or by the --prefer rule:
+  keep     - keep the KEEP_ID value
+  drop     - take the DROP_ID value
+  longest  - take the longer value`,
+	"максимальное число опечаток в латинском названии (0 - только точные совпадения)": "maximum number of typos in the latin name (0 - exact matches only)",
+	"правило разрешения конфликтов: keep, drop или longest":                           "conflict rule: keep, drop or longest",
+	"объединить без подтверждения":                                                    "merge without confirmation",
+	"Возможных дубликатов не найдено.":                                                "No possible duplicates found.",
+	"Найдено групп возможных дубликатов: %d\n":                                        "Groups of possible duplicates found: %d\n",
+	"\nГруппа %d:\n": "\nGroup %d:\n",
+	"\nДля объединения используйте 'herb merge KEEP_ID DROP_ID'.":                       "\nUse 'herb merge KEEP_ID DROP_ID' to merge them.",
+	"нельзя объединить траву саму с собой":                                              "cannot merge a herb with itself",
+	"неизвестное правило --prefer: %s (доступно: keep, drop, longest)":                  "unknown --prefer rule: %s (available: keep, drop, longest)",
+	"ввод не является терминалом: укажите правило разрешения конфликтов через --prefer": "input is not a terminal: give a conflict rule with --prefer",
+	"Трава ID %d будет объединена с травой ID %d и удалена.\n":                          "Herb ID %d will be merged into herb ID %d and deleted.\n",
+	"Объединение отменено.":                                                             "Merge cancelled.",
+	"не удалось объединить травы: %v":                                                   "failed to merge herbs: %v",
+	"✅ Трава с ID %d объединена с травой ID %d\n":                                       "✅ Herb with ID %d merged into herb ID %d\n",
+	"\nПоле %s различается:\n":                                                          "\nField %s differs:\n",
+	"Выберите значение (1/2) [1]: ":                                                     "Choose a value (1/2) [1]: ",
+
+	// cmd/names.go
+	"Другие названия травы": "Alternative herb names",
+	`Народные названия на разных языках и устаревшие научные синонимы травы.
+Поиск (herb search) находит траву по любому из ее названий.`: `Vernacular names in different languages and obsolete scientific synonyms of a herb.
+Search (herb search) finds a herb by any of its names.`,
+	"Добавить название травы": "Add a herb name",
+	`Добавляет траве другое название.
+
+Типы названий:
+  vernacular  - народное название (по умолчанию)
+  synonym     - устаревший научный синоним
+  accepted    - принятое научное название`: `Adds an alternative name to a herb.
+
+Name types:
+  vernacular  - vernacular name (default)
+  synonym     - obsolete scientific synonym
+  accepted    - accepted scientific name`,
+	"Удалить название травы":                          "Delete a herb name",
+	"код языка: ru, en, la и т.п.":                    "language code: ru, en, la etc.",
+	"тип названия: vernacular, synonym или accepted":  "name type: vernacular, synonym or accepted",
+	"Другие названия:":                                "Other names:",
+	"не удалось добавить название: %w":                "failed to add name: %w",
+	"✅ Траве «%s» добавлено название «%s» с ID: %d\n": "✅ Herb «%s» now has the name «%s» with ID: %d\n",
+	"✅ Название с ID %d удалено\n":                    "✅ Name with ID %d deleted\n",
+
+	// cmd/output.go
+	"формат вывода (text, json, csv)": "output format (text, json, csv)",
+	"шаблон Go text/template для каждой травы или имя шаблона из каталога шаблонов": "Go text/template for each herb, or the name of a template from the templates directory",
+	"флаги --format и --output нельзя использовать вместе":                          "--format and --output cannot be used together",
+	"неизвестный формат вывода: %s (доступно: text, json, csv)":                     "unknown output format: %s (available: text, json, csv)",
+
+	// cmd/prompt.go
+	"требуется подтверждение, но ввод не является терминалом; используйте --yes": "confirmation required but input is not a terminal; use --yes",
+
+	// cmd/regions.go
+	"Регионы произрастания трав": "Regions where herbs grow",
+	`Регионы, в которых растут травы. Регионы образуют иерархию, например
+Россия → Сибирь → Алтайский край: травы региона включают травы всех его подрегионов.
+
+Трава привязывается к региону командой herb season.`: `Regions where herbs grow. Regions form a hierarchy, e.g.
+Russia → Siberia → Altai Krai: the herbs of a region include the herbs of all its sub-regions.
+
+A herb is linked to a region with herb season.`,
+	"Добавить регион":          "Add a region",
+	"Показать все регионы":     "Show all regions",
+	"Показать дерево регионов": "Show the region tree",
+	`Выводит иерархию регионов с количеством трав в каждом регионе
+(включая травы его подрегионов; трава, растущая в нескольких подрегионах,
+считается один раз).`: `Prints the region hierarchy with the number of herbs in each region
+(including the herbs of its sub-regions; a herb growing in several sub-regions
+is counted once).`,
+	"Изменить родительский регион": "Change the parent region",
+	`Переносит регион (ID или название) в регион из --parent или, с --root,
+делает его регионом верхнего уровня. Регион нельзя перенести в него самого
+или в один из его подрегионов.`: `Moves a region (ID or name) into the region given in --parent or, with --root,
+makes it a top-level region. A region cannot be moved into itself
+or into one of its sub-regions.`,
+	"родительский регион (ID или название)":                  "parent region (ID or name)",
+	"описание региона":                                       "region description",
+	"новый родительский регион (ID или название)":            "new parent region (ID or name)",
+	"сделать регион регионом верхнего уровня":                "make the region a top-level region",
+	"%s [%d] — трав: %d":                                     "%s [%d] — herbs: %d",
+	"не удалось добавить регион: %w":                         "failed to add region: %w",
+	"✅ Регион «%s» добавлен с ID: %d\n":                      "✅ Region «%s» added with ID: %d\n",
+	"Регионы не найдены":                                     "No regions found",
+	" (в регионе «%s»)":                                      " (in region «%s»)",
+	"не удалось получить регионы: %v":                        "failed to get regions: %v",
+	"Регионы не найдены. Добавьте их командой 'region add'.": "No regions found. Add them with 'region add'.",
+	"укажите ровно один из флагов --parent и --root":         "specify exactly one of --parent and --root",
+	"✅ Регион «%s» перенесен в регион «%s»\n":                "✅ Region «%s» moved into region «%s»\n",
+	"✅ Регион «%s» стал регионом верхнего уровня\n":          "✅ Region «%s» is now a top-level region\n",
+
+	// cmd/root.go
+	"CLI для управления базой данных лекарственных трав": "CLI for managing a medicinal herbs database",
+	`Приложение командной строки для выполнения CRUD операций 
+с базой данных лекарственных трав.
+
+Поддерживаемые операции:
+- Создание новых записей о травах
+- Просмотр информации о травах
+- Обновление данных о травах
+- Удаление записей о травах
+- Поиск трав по названию`: `A command-line application for CRUD operations
+on a medicinal herbs database.
+
+Supported operations:
+- Creating herb records
+- Viewing herbs
+- Updating herbs
+- Deleting herb records
+- Searching herbs by name`,
+	"Ошибка настройки языка: %v\n":                                                         "Language setup error: %v\n",
+	"Ошибка настройки часового пояса: %v\n":                                                "Timezone setup error: %v\n",
+	"Ошибка подключения к базе данных: %v\n":                                               "Database connection error: %v\n",
+	"Ошибка закрытия соединения с БД: %v":                                                  "Error closing the database connection: %v",
+	"файл конфигурации (по умолчанию $HOME/.herbs-cli.yaml)":                               "config file (default $HOME/.herbs-cli.yaml)",
+	"часовой пояс для вывода дат, например Europe/Moscow или UTC (по умолчанию локальный)": "timezone for displayed dates, e.g. Europe/Moscow or UTC (default local)",
+	"имя, под которым сохраняются изменения записей":                                       "name recorded as the author of changes",
+	"язык сообщений: ru или en (по умолчанию определяется по LANG)":                        "message language: ru or en (default taken from LANG)",
+	"вывод без эмодзи и декоративных символов":                                             "output without emoji and decorative symbols",
+	"адрес сервера PostgreSQL":                                                             "PostgreSQL server host",
+	"порт PostgreSQL":                                                                      "PostgreSQL port",
+	"имя пользователя PostgreSQL":                                                          "PostgreSQL user",
+	"пароль PostgreSQL":                                                                    "PostgreSQL password",
+	"имя базы данных":                                                                      "database name",
+	"режим SSL (disable, require, verify-ca, verify-full)":                                 "SSL mode (disable, require, verify-ca, verify-full)",
+	"неизвестный часовой пояс %q: %v":                                                      "unknown timezone %q: %v",
+	"Используется конфигурационный файл: %s\n":                                             "Using config file: %s\n",
+
+	// cmd/safety.go
+	"Противопоказания трав": "Herb contraindications",
+	`Противопоказания трав: беременность, детский возраст, заболевания.
+Противопоказания выводятся в herb get и проверяются командой check.`: `Herb contraindications: pregnancy, childhood, diseases.
+Contraindications are shown by herb get and checked by the check command.`,
+	"Добавить противопоказание":         "Add a contraindication",
+	"Удалить противопоказание":          "Delete a contraindication",
+	"Взаимодействия трав с лекарствами": "Herb-drug interactions",
+	`Известные взаимодействия трав с лекарствами и группами лекарств.
+Взаимодействия выводятся в herb get и проверяются командой check.`: `Known interactions of herbs with drugs and drug classes.
+Interactions are shown by herb get and checked by the check command.`,
+	"Добавить взаимодействие с лекарством":           "Add a drug interaction",
+	"Удалить взаимодействие":                         "Delete an interaction",
+	"степень серьезности: minor, moderate или major": "severity: minor, moderate or major",
+	"пояснение": "notes",
+	"описание последствий взаимодействия":                         "description of the interaction effect",
+	"Противопоказания:":                                           "Contraindications:",
+	"Взаимодействия с лекарствами:":                               "Drug interactions:",
+	"не удалось добавить противопоказание: %w":                    "failed to add contraindication: %w",
+	"✅ Противопоказание «%s» для травы «%s» добавлено с ID: %d\n": "✅ Contraindication «%s» for herb «%s» added with ID: %d\n",
+	"✅ Противопоказание с ID %d удалено\n":                        "✅ Contraindication with ID %d deleted\n",
+	"не удалось добавить взаимодействие: %w":                      "failed to add interaction: %w",
+	"✅ Взаимодействие травы «%s» с «%s» добавлено с ID: %d\n":     "✅ Interaction of herb «%s» with «%s» added with ID: %d\n",
+	"✅ Взаимодействие с ID %d удалено\n":                          "✅ Interaction with ID %d deleted\n",
+
+	// cmd/season.go
+	"месяцы цветения, например 6-8 или июн-авг": "flowering months, e.g. 6-8 or jun-aug",
+	"месяцы сбора, например 7,8 или 11-2":       "harvest months, e.g. 7,8 or 11-2",
+
+	// cmd/shell.go
+	"Интерактивная оболочка": "Interactive shell",
+	`Запускает интерактивную оболочку с одним постоянным подключением к базе данных.
+В оболочке доступны все команды herb (слово "herb" можно не писать), история
+команд (стрелки вверх и вниз) и дополнение команд и названий трав по Tab.
+
+Команды оболочки:
+  use ID|название  - выбрать текущую траву; get, update, edit и delete без ID
+                     работают с ней, а символ @ заменяется ее ID
+  unuse            - сбросить текущую траву
+  help             - показать справку
+  exit, quit       - выйти (или Ctrl+D)`: `Starts an interactive shell with a single persistent database connection.
+All herb commands are available (the word "herb" may be omitted), with command
+history (up and down arrows) and Tab completion of commands and herb names.
+
+Shell commands:
+  use ID|name      - select the current herb; get, update, edit and delete without
+                     an ID work on it, and @ is replaced with its ID
+  unuse            - clear the current herb
+  help             - show help
+  exit, quit       - quit (or Ctrl+D)`,
+	"оболочка уже запущена":                                          "the shell is already running",
+	"для оболочки нужен терминал":                                    "the shell requires a terminal",
+	"Оболочка herbs-cli. Введите help для справки, exit для выхода.": "herbs-cli shell. Type help for help, exit to quit.",
+	"не удалось перевести терминал в интерактивный режим: %v":        "failed to switch the terminal to interactive mode: %v",
+	"❌ оболочка уже запущена":                                        "❌ the shell is already running",
+	"Текущая трава не выбрана.":                                      "No current herb selected.",
+	"❌ трава '%s' не найдена\n":                                      "❌ herb '%s' not found\n",
+	"Под '%s' подходит несколько трав, укажите ID:\n":                "Several herbs match '%s', give an ID:\n",
+	"незакрытая кавычка":                                             "unterminated quote",
+	"Не удалось сохранить историю команд: %v\n":                      "Failed to save the command history: %v\n",
+
+	// cmd/sources.go
+	"Источники сведений о травах": "Sources of information about herbs",
+	`Книги, статьи и сайты, на которые ссылаются сведения о травах.
+
+Ссылки на источники добавляются командой herb cite для описания травы,
+ее токсичности или отдельного способа применения и выводятся в herb get --sources.`: `Books, articles and websites that the information about herbs refers to.
+
+Citations are added with herb cite for the description of a herb,
+its toxicity or a single usage, and are shown by herb get --sources.`,
+	"Добавить источник": "Add a source",
+	`Добавляет источник. Авторы указываются по одному в --author в виде
+"Фамилия, Имя"; название организации указывается без запятой.`: `Adds a source. Authors are given one per --author as
+"Family, Given"; the name of an organization is given without a comma.`,
+	"Показать все источники":                             "Show all sources",
+	"Удалить источник":                                   "Delete a source",
+	"Удаляет источник вместе со всеми ссылками на него.": "Deletes the source together with all citations of it.",
+	"Экспортировать источники в BibTeX или CSL-JSON":     "Export sources to BibTeX or CSL-JSON",
+	`Выводит источники, на которые ссылаются сведения об указанных травах,
+или, без аргументов, все источники в формате BibTeX или CSL-JSON.`: `Prints the sources cited for the given herbs
+or, without arguments, all sources in BibTeX or CSL-JSON format.`,
+	"Сослаться на источник сведений о траве": "Cite a source for information about a herb",
+	`Указывает источник сведений о траве.
+
+Сведения (--fact):
+  herb      - описание травы (по умолчанию)
+  toxicity  - токсичность
+  usage     - способ применения с ID из --usage`: `Cites a source for information about a herb.
+
+Information (--fact):
+  herb      - the description of the herb (default)
+  toxicity  - toxicity
+  usage     - the usage with the ID from --usage`,
+	"Удалить ссылку на источник":                          "Delete a citation",
+	"вид источника: book, article, website или other":     "source kind: book, article, website or other",
+	"автор в виде \"Фамилия, Имя\"; флаг можно повторять": "author as \"Family, Given\"; the flag can be repeated",
+	"год издания": "publication year",
+	"журнал статьи или название сайта":                    "journal of the article or name of the website",
+	"издательство":                                        "publisher",
+	"том":                                                 "volume",
+	"номер выпуска":                                       "issue number",
+	"страницы статьи, например 100-110":                   "pages of the article, e.g. 100-110",
+	"адрес в интернете":                                   "web address",
+	"формат: bibtex или csl-json":                         "format: bibtex or csl-json",
+	"сведения: herb, toxicity или usage":                  "information: herb, toxicity or usage",
+	"ID способа применения":                               "usage ID",
+	"страницы источника, например 45-47":                  "pages of the source, e.g. 45-47",
+	"Источники не указаны.":                               "No sources given.",
+	"Источники:":                                          "Sources:",
+	", с. %s":                                             ", p. %s",
+	"не удалось добавить источник: %w":                    "failed to add source: %w",
+	"✅ Источник добавлен с ID: %d\n":                      "✅ Source added with ID: %d\n",
+	"Источники не найдены":                                "No sources found",
+	"✅ Источник с ID %d удален\n":                         "✅ Source with ID %d deleted\n",
+	"неизвестный формат: %s (доступно: bibtex, csl-json)": "unknown format: %s (available: bibtex, csl-json)",
+	"не удалось добавить ссылку на источник: %w":          "failed to add citation: %w",
+	"✅ Для травы «%s» (%s) указан источник «%s», ID ссылки: %d\n": "✅ Herb «%s» (%s) now cites source «%s», citation ID: %d\n",
+	"✅ Ссылка на источник с ID %d удалена\n":                      "✅ Citation with ID %d deleted\n",
+
+	// cmd/taxonomy.go
+	"Работа с таксономией трав": "Work with the herb taxonomy",
+	`Команды для работы с иерархией таксонов: семейство → род → вид.
+
+Роды и виды создаются автоматически по латинским названиям трав;
+семейства добавляются командой taxonomy set.`: `Commands for the taxon hierarchy: family → genus → species.
+
+Genera and species are created automatically from the latin names of herbs;
+families are added with taxonomy set.`,
+	"Показать дерево таксонов": "Show the taxon tree",
+	`Выводит иерархию семейств, родов и видов с количеством трав
+в каждом таксоне (включая травы вложенных таксонов).`: `Prints the hierarchy of families, genera and species with the number of herbs
+in each taxon (including the herbs of nested taxa).`,
+	"Добавить таксон или изменить его родителя": "Add a taxon or change its parent",
+	`Создает таксон ранга RANK (family, genus или species) с названием NAME.
+Если такой таксон уже есть, он переносится к родителю из --parent.
+
+Родителем рода может быть только семейство, родителем вида - только род.
+Вид переносится только к роду из его биномиального названия; чтобы
+перенести его к другому роду, укажите --force.`: `Creates a taxon of rank RANK (family, genus or species) named NAME.
+If the taxon already exists, it is moved under the parent from --parent.
+
+The parent of a genus can only be a family, the parent of a species only a genus.
+A species is only moved under the genus of its binomial name; use
+--force to move it under another genus.`,
+	"название родительского таксона":                        "name of the parent taxon",
+	"перенести вид к роду, не совпадающему с его названием": "move a species under a genus that does not match its name",
+	"%s (%s) — трав: %d":              "%s (%s) — herbs: %d",
+	"не удалось получить таксоны: %v": "failed to get taxa: %v",
+	"Таксоны не найдены. Укажите латинские названия трав или добавьте таксоны командой 'taxonomy set'.": "No taxa found. Set the latin names of herbs or add taxa with 'taxonomy set'.",
+	"\nТрав без таксона: %d\n":                  "\nHerbs without a taxon: %d\n",
+	"у таксона ранга %s не может быть родителя": "a taxon of rank %s cannot have a parent",
+	"вид «%s» относится к роду «%s», а не «%s»; укажите --force, чтобы все равно перенести его": "species «%s» belongs to genus «%s», not «%s»; use --force to move it anyway",
+	"не удалось сохранить таксон: %w":        "failed to save taxon: %w",
+	"✅ Таксон «%s» (%s) сохранен с ID: %d\n": "✅ Taxon «%s» (%s) saved with ID: %d\n",
+
+	// cmd/template.go
+	"не удалось определить каталог конфигурации: %v": "failed to find the config directory: %v",
+	"ошибка в шаблоне --format: %v":                  "error in the --format template: %v",
+	"шаблон %s не найден в каталоге %s":              "template %s not found in %s",
+	"ошибка в шаблоне %s: %v":                        "error in template %s: %v",
+	"ошибка выполнения шаблона: %v":                  "template execution failed: %v",
+	"join: ожидается список, получено %T":            "join: expected a list, got %T",
+	"pluck: ожидается список, получено %T":           "pluck: expected a list, got %T",
+	"pluck: элемент %s не является структурой":       "pluck: element %s is not a struct",
+	"pluck: у %s нет поля %s":                        "pluck: %s has no field %s",
+
+	// cmd/toxicity.go
+	"степень токсичности: none, mild, moderate, severe или deadly":                                                         "toxicity level: none, mild, moderate, severe or deadly",
+	"ядовитые части растения через запятую: whole, root, rhizome, bulb, stem, bark, leaf, flower, fruit, berry, seed, sap": "comma-separated toxic plant parts: whole, root, rhizome, bulb, stem, bark, leaf, flower, fruit, berry, seed, sap",
+	"токсичные вещества через запятую":                                                                                     "comma-separated toxic compounds",
+	"симптомы отравления через запятую":                                                                                    "comma-separated poisoning symptoms",
+
+	// cmd/tui.go
+	"Полноэкранный просмотр каталога трав": "Full-screen herb catalog browser",
+	`Открывает полноэкранный интерфейс со списком трав и подробной информацией
+о выбранной траве: регионами и способами применения.
+
+Клавиши:
+  ↑/↓, j/k, PgUp/PgDn  - перемещение по списку
+  /                    - поиск по названию (Enter - готово, Esc - сбросить)
+  p                    - показывать только ядовитые травы
+  e                    - редактировать траву в $EDITOR
+  d                    - удалить траву
+  r                    - обновить список
+  q, Ctrl+C            - выход`: `Opens a full-screen interface with the list of herbs and details
+of the selected herb: its regions and usages.
+
+Keys:
+  ↑/↓, j/k, PgUp/PgDn  - move through the list
+  /                    - search by name (Enter - done, Esc - clear)
+  p                    - show only poisonous herbs
+  e                    - edit the herb in $EDITOR
+  d                    - delete the herb
+  r                    - refresh the list
+  q, Ctrl+C            - quit`,
+	"для полноэкранного режима нужен терминал":     "full-screen mode requires a terminal",
+	"Удалить «%s» (ID %d)? (y/n)":                  "Delete «%s» (ID %d)? (y/n)",
+	"\nНажмите Enter, чтобы вернуться к списку...": "\nPress Enter to return to the list...",
+	"✅ Трава с ID %d удалена":                      "✅ Herb with ID %d deleted",
+	" herbs-cli — трав: %d":                        " herbs-cli — herbs: %d",
+	"  поиск: %s":                                  "  search: %s",
+	"  [только ядовитые]":                          "  [poisonous only]",
+	"Латинское":                                    "Latin",
+	"Яд":                                           "Pois",
+	"ДА":                                           "YES",
+	" ↑↓ выбор  / поиск  p ядовитые  e правка  d удалить  r обновить  q выход": " ↑↓ select  / search  p poisonous  e edit  d delete  r refresh  q quit",
+	"Регионы: ":   "Regions: ",
+	"Применение:": "Usage:",
+
+	// cmd/usages.go
+	"Способы применения трав": "Herb usages",
+	`Способы применения трав с дозировкой: часть растения, способ приготовления,
+количество с единицей измерения, частота приема и продолжительность курса.`: `Herb usages with dosage: plant part, preparation method,
+amount with a unit, frequency and course duration.`,
+	"Показать способы применения трав": "Show herb usages",
+	`Выводит способы применения указанных трав или, без аргументов, всех трав.
+
+С --unit количество переводится в указанную единицу измерения; количество,
+которое нельзя перевести (например, миллилитры в граммы), выводится как есть.`: `Prints the usages of the given herbs or, without arguments, of all herbs.
+
+With --unit amounts are converted to the given unit; amounts that
+cannot be converted (for example millilitres to grams) are printed as is.`,
+	"Добавить способ применения": "Add a usage",
+	`Добавляет траве способ применения. Тип использования задается ID или названием.
+
+Части растения: whole, root, rhizome, bulb, stem, bark, leaf, flower, fruit, berry, seed, sap.
+Способы приготовления: infusion, decoction, tincture, extract, tea, powder, juice,
+oil, ointment, syrup, compress, bath, fresh.
+Единицы измерения: mg, g, kg, ml, l, tsp, tbsp, drop, piece (а также г, мл, ч.л., ст.л. и т.п.).`: `Adds a usage to a herb. The usage type is given by its ID or name.
+
+Plant parts: whole, root, rhizome, bulb, stem, bark, leaf, flower, fruit, berry, seed, sap.
+Preparation methods: infusion, decoction, tincture, extract, tea, powder, juice,
+oil, ointment, syrup, compress, bath, fresh.
+Units: mg, g, kg, ml, l, tsp, tbsp, drop, piece (as well as г, мл, ч.л., ст.л. etc.).`,
+	"Удалить способ применения":                                       "Delete a usage",
+	"перевести количество в единицу измерения: mg, g, kg, ml, l":      "convert amounts to a unit: mg, g, kg, ml, l",
+	"часть растения: leaf, flower, root и т.п.":                       "plant part: leaf, flower, root etc.",
+	"способ приготовления: infusion, decoction, tincture и т.п.":      "preparation method: infusion, decoction, tincture etc.",
+	"количество на один прием":                                        "amount per dose",
+	"единица измерения количества: mg, g, ml, tsp, tbsp, drop и т.п.": "unit of the amount: mg, g, ml, tsp, tbsp, drop etc.",
+	"сколько раз в день":                                              "times a day",
+	"продолжительность курса в днях":                                  "course duration in days",
+	"описание применения":                                             "usage description",
+	"тип использования «%s» не найден":                                "usage type «%s» not found",
+	"Способы применения не найдены":                                   "No usages found",
+	"не удалось добавить способ применения: %w":                       "failed to add usage: %w",
+	"✅ Траве «%s» добавлен способ применения с ID: %d\n":              "✅ Usage added to herb «%s» with ID: %d\n",
+	"✅ Способ применения с ID %d удален\n":                            "✅ Usage with ID %d deleted\n",
+
+	// cmd/wizard.go
+	"ввод прерван":         "input aborted",
+	"  ❌ ответьте y или n": "  ❌ answer y or n",
+	"нет записи с ID %d":   "no record with ID %d",
+	"Создание новой травы. Нажмите Ctrl+D, чтобы прервать.": "Creating a new herb. Press Ctrl+D to abort.",
+	"Создание отменено.":                                          "Creation cancelled.",
+	"\nБудет создана трава:":                                      "\nThe following herb will be created:",
+	"Применение (%s): %s\n":                                       "Usage (%s): %s\n",
+	"Сохранить?":                                                  "Save?",
+	"Название: ":                                                  "Name: ",
+	"Латинское название (необязательно): ":                        "Latin name (optional): ",
+	"Описание (необязательно): ":                                  "Description (optional): ",
+	"Степень токсичности (%s, Enter - none): ":                    "Toxicity level (%s, Enter - none): ",
+	"Путь к изображению (необязательно): ":                        "Image path (optional): ",
+	"\nРегионы произрастания:":                                    "\nRegions where it grows:",
+	"ID регионов через запятую (Enter - пропустить): ":            "Comma-separated region IDs (Enter - skip): ",
+	"\nТипы использования:":                                       "\nUsage types:",
+	"ID типов использования через запятую (Enter - пропустить): ": "Comma-separated usage type IDs (Enter - skip): ",
+	"Описание применения (%s): ":                                  "Usage description (%s): ",
+}
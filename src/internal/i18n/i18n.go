@@ -0,0 +1,154 @@
+// Package i18n translates user-facing messages. Messages are written in Russian
+// in the code and used as keys of the catalogs for other languages, so an untranslated
+// message is simply shown in Russian.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Languages lists the supported language codes; the first one is the language of the keys
+var Languages = []string{"ru", "en"}
+
+// catalogs maps a language code to its translations
+var catalogs = map[string]map[string]string{
+	"en": english,
+}
+
+var (
+	language = "ru"
+	plain    bool
+)
+
+// SetLanguage selects the language of messages. An empty code selects the
+// language from the environment.
+func SetLanguage(code string) error {
+	if code == "" {
+		code = DetectLanguage()
+	}
+	code = strings.ToLower(code)
+	for _, known := range Languages {
+		if code == known {
+			language = code
+			return nil
+		}
+	}
+	return Errorf("неизвестный язык: %s (доступно: %s)", code, strings.Join(Languages, ", "))
+}
+
+// Language returns the code of the selected language
+func Language() string {
+	return language
+}
+
+// DetectLanguage picks the language from LC_ALL, LC_MESSAGES or LANG.
+// Russian is used when the locale is not set or is the C/POSIX locale.
+func DetectLanguage() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		locale := os.Getenv(name)
+		if locale == "" {
+			continue
+		}
+		switch {
+		case locale == "C" || locale == "POSIX" || strings.HasPrefix(locale, "C."):
+			return "ru"
+		case strings.HasPrefix(strings.ToLower(locale), "ru"):
+			return "ru"
+		default:
+			return "en"
+		}
+	}
+	return "ru"
+}
+
+// SetPlain turns the plain mode without emoji and decorative symbols on or off
+func SetPlain(on bool) {
+	plain = on
+}
+
+// Plain reports whether the plain mode is on
+func Plain() bool {
+	return plain
+}
+
+// T returns the translation of msg into the selected language.
+// In plain mode emoji and decorative symbols are removed from the result.
+func T(msg string) string {
+	if translated, ok := catalogs[language][msg]; ok {
+		msg = translated
+	}
+	if plain {
+		msg = StripDecorations(msg)
+	}
+	return msg
+}
+
+// Errorf formats an error with a translated format string
+func Errorf(format string, args ...any) error {
+	return fmt.Errorf(T(format), args...)
+}
+
+// decorations are replaced with ASCII in plain mode
+var decorations = strings.NewReplacer(
+	"→", "->",
+	"•", "-",
+	"«", `"`,
+	"»", `"`,
+	"—", "-",
+	"▏", "|",
+)
+
+// StripDecorations removes emoji and replaces decorative symbols with ASCII
+func StripDecorations(s string) string {
+	runes := []rune(s)
+	out := make([]rune, 0, len(runes))
+	for i := 0; i < len(runes); i++ {
+		if !isEmoji(runes[i]) {
+			out = append(out, runes[i])
+			continue
+		}
+
+		// Drop the space that separated the emoji from the text
+		for i+1 < len(runes) && isEmoji(runes[i+1]) {
+			i++
+		}
+		switch {
+		case i+1 < len(runes) && runes[i+1] == ' ':
+			for i+1 < len(runes) && runes[i+1] == ' ' {
+				i++
+			}
+		case i+1 == len(runes) || runes[i+1] == '\n':
+			for len(out) > 0 && out[len(out)-1] == ' ' {
+				out = out[:len(out)-1]
+			}
+		}
+	}
+	return decorations.Replace(string(out))
+}
+
+// isEmoji reports whether r is an emoji, a pictograph or an emoji variation selector
+func isEmoji(r rune) bool {
+	switch {
+	case r == 0xFE0F, r == 0x200D:
+		return true
+	case r >= 0x2600 && r <= 0x27BF: // miscellaneous symbols and dingbats
+		return true
+	case r >= 0x1F000 && r <= 0x1FAFF:
+		return true
+	}
+	return false
+}
+
+// NewError returns an error whose message is translated each time it is shown.
+// It is meant for package-level errors created before the language is selected.
+func NewError(msg string) error {
+	return lazyError(msg)
+}
+
+type lazyError string
+
+func (e lazyError) Error() string {
+	return T(string(e))
+}
@@ -0,0 +1,187 @@
+package models
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+)
+
+// Months is a sorted set of months numbered from 1 (January) to 12 (December)
+type Months []int
+
+// monthPrefixes are the month names accepted by ParseMonths, matched by their
+// first three letters: English and Russian
+var monthPrefixes = [][]string{
+	{"jan", "янв"}, {"feb", "фев"}, {"mar", "мар"}, {"apr", "апр"},
+	{"may", "мая", "май"}, {"jun", "июн"}, {"jul", "июл"}, {"aug", "авг"},
+	{"sep", "сен"}, {"oct", "окт"}, {"nov", "ноя"}, {"dec", "дек"},
+}
+
+// ParseMonth parses a month given by its number or name
+func ParseMonth(s string) (int, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if month, err := strconv.Atoi(s); err == nil {
+		if month < 1 || month > 12 {
+			return 0, i18n.Errorf("месяц должен быть от 1 до 12: %s", s)
+		}
+		return month, nil
+	}
+
+	if runes := []rune(s); len(runes) >= 3 {
+		prefix := string(runes[:3])
+		for i, names := range monthPrefixes {
+			for _, name := range names {
+				if prefix == name {
+					return i + 1, nil
+				}
+			}
+		}
+	}
+	return 0, i18n.Errorf("неизвестный месяц: %s", s)
+}
+
+// ParseMonths parses a comma-separated list of months and ranges such as
+// "6-8,10" or "июн-авг". A range may wrap around the end of the year: "11-2".
+func ParseMonths(s string) (Months, error) {
+	months := Months{}
+	for _, item := range strings.Split(s, ",") {
+		if strings.TrimSpace(item) == "" {
+			continue
+		}
+		from, to, isRange := strings.Cut(item, "-")
+		first, err := ParseMonth(from)
+		if err != nil {
+			return nil, err
+		}
+		last := first
+		if isRange {
+			if last, err = ParseMonth(to); err != nil {
+				return nil, err
+			}
+		}
+		for month := first; ; month = month%12 + 1 {
+			months = append(months, month)
+			if month == last {
+				break
+			}
+		}
+	}
+	return months.Normalize(), nil
+}
+
+// Normalize sorts the months and removes repeated ones
+func (m Months) Normalize() Months {
+	seen := make(map[int]bool, len(m))
+	normalized := Months{}
+	for _, month := range m {
+		if !seen[month] {
+			seen[month] = true
+			normalized = append(normalized, month)
+		}
+	}
+	sort.Ints(normalized)
+	return normalized
+}
+
+// Contains reports whether month is in the set
+func (m Months) Contains(month int) bool {
+	for _, value := range m {
+		if value == month {
+			return true
+		}
+	}
+	return false
+}
+
+// Valid reports whether every month is between 1 and 12
+func (m Months) Valid() bool {
+	for _, month := range m {
+		if month < 1 || month > 12 {
+			return false
+		}
+	}
+	return true
+}
+
+// ranges groups the months into runs of consecutive months; a run that
+// crosses the new year, such as November to February, is kept whole
+func (m Months) ranges() [][2]int {
+	var runs [][2]int
+	for _, month := range m.Normalize() {
+		if n := len(runs); n > 0 && runs[n-1][1] == month-1 {
+			runs[n-1][1] = month
+			continue
+		}
+		runs = append(runs, [2]int{month, month})
+	}
+
+	if n := len(runs); n > 1 && runs[0][0] == 1 && runs[n-1][1] == 12 {
+		runs[n-1][1] = runs[0][1]
+		runs = runs[1:]
+	}
+	return runs
+}
+
+// format joins the runs of months written with name
+func (m Months) format(name func(int) string, dash, separator string) string {
+	var parts []string
+	for _, run := range m.ranges() {
+		if run[0] == run[1] {
+			parts = append(parts, name(run[0]))
+		} else {
+			parts = append(parts, name(run[0])+dash+name(run[1]))
+		}
+	}
+	return strings.Join(parts, separator)
+}
+
+// String formats the months as ParseMonths reads them, e.g. "6-8,10"
+func (m Months) String() string {
+	return m.format(strconv.Itoa, "-", ",")
+}
+
+// Label formats the months with their translated names, e.g. "июн–авг, окт"
+func (m Months) Label() string {
+	return m.format(MonthLabel, "–", ", ")
+}
+
+// MonthLabel returns the translated short name of a month
+func MonthLabel(month int) string {
+	switch month {
+	case 1:
+		return i18n.T("янв")
+	case 2:
+		return i18n.T("фев")
+	case 3:
+		return i18n.T("мар")
+	case 4:
+		return i18n.T("апр")
+	case 5:
+		return i18n.T("май")
+	case 6:
+		return i18n.T("июн")
+	case 7:
+		return i18n.T("июл")
+	case 8:
+		return i18n.T("авг")
+	case 9:
+		return i18n.T("сен")
+	case 10:
+		return i18n.T("окт")
+	case 11:
+		return i18n.T("ноя")
+	case 12:
+		return i18n.T("дек")
+	}
+	return strconv.Itoa(month)
+}
+
+// HerbSeason - сроки цветения и сбора травы в целом или в одном из регионов
+type HerbSeason struct {
+	Herb      Herb   `json:"herb"`
+	Region    string `json:"region,omitempty"` // empty for the months of the herb itself
+	Flowering Months `json:"flowering_months"`
+	Harvest   Months `json:"harvest_months"`
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMonths(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Months
+		str     string
+		wantErr bool
+	}{
+		{in: "7", want: Months{7}, str: "7"},
+		{in: "6-8,10", want: Months{6, 7, 8, 10}, str: "6-8,10"},
+		{in: "11-2", want: Months{1, 2, 11, 12}, str: "11-2"},
+		{in: "12-1", want: Months{1, 12}, str: "12-1"},
+		{in: "1-12", want: Months{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, str: "1-12"},
+		{in: "5-5", want: Months{5}, str: "5"},
+		{in: "июн-авг", want: Months{6, 7, 8}, str: "6-8"},
+		{in: "Jun-Aug, oct", want: Months{6, 7, 8, 10}, str: "6-8,10"},
+		{in: "мая", want: Months{5}, str: "5"},
+		{in: "8,7,7,6", want: Months{6, 7, 8}, str: "6-8"},
+		{in: "", want: Months{}, str: ""},
+		{in: " , ", want: Months{}, str: ""},
+		{in: "0", wantErr: true},
+		{in: "13", wantErr: true},
+		{in: "11-13", wantErr: true},
+		{in: "ju", wantErr: true},
+		{in: "abc-2", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseMonths(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseMonths(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseMonths(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+		if got.String() != tt.str {
+			t.Errorf("ParseMonths(%q).String() = %q, want %q", tt.in, got.String(), tt.str)
+		}
+	}
+}
@@ -0,0 +1,269 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+)
+
+// Dimensions of dosage units; amounts convert only within a dimension
+const (
+	DimensionMass   = "mass"
+	DimensionVolume = "volume"
+	DimensionCount  = "count"
+)
+
+// unit describes a dosage unit by its dimension and its size in the base
+// unit of that dimension (grams, millilitres or pieces)
+type unit struct {
+	dimension string
+	factor    float64
+}
+
+// units are the supported dosage units. Spoons and drops are the usual
+// household measures and are taken at 5 ml, 15 ml and 0.05 ml.
+var units = map[string]unit{
+	"mg":    {DimensionMass, 0.001},
+	"g":     {DimensionMass, 1},
+	"kg":    {DimensionMass, 1000},
+	"ml":    {DimensionVolume, 1},
+	"l":     {DimensionVolume, 1000},
+	"tsp":   {DimensionVolume, 5},
+	"tbsp":  {DimensionVolume, 15},
+	"drop":  {DimensionVolume, 0.05},
+	"piece": {DimensionCount, 1},
+}
+
+// Units lists the names of the supported dosage units
+var Units = []string{"mg", "g", "kg", "ml", "l", "tsp", "tbsp", "drop", "piece"}
+
+// unitAliases are the other spellings accepted by ParseUnit
+var unitAliases = map[string]string{
+	"мг": "mg", "г": "g", "гр": "g", "кг": "kg",
+	"мл": "ml", "л": "l",
+	"ч.л.": "tsp", "чл": "tsp", "ст.л.": "tbsp", "стл": "tbsp",
+	"кап": "drop", "кап.": "drop", "drops": "drop",
+	"шт": "piece", "шт.": "piece", "pcs": "piece", "pieces": "piece",
+}
+
+// ParseUnit returns the name of a unit given by its name or a common abbreviation
+func ParseUnit(s string) (string, error) {
+	s = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(s), " ", ""))
+	if alias, ok := unitAliases[s]; ok {
+		s = alias
+	}
+	if _, ok := units[s]; !ok {
+		return "", i18n.Errorf("неизвестная единица измерения: %s (доступно: %s)", s, strings.Join(Units, ", "))
+	}
+	return s, nil
+}
+
+// UnitDimension returns the dimension of a unit, or "" if the unit is unknown
+func UnitDimension(name string) string {
+	return units[name].dimension
+}
+
+// MinAmount is the smallest amount that can be stored: amounts are kept with
+// three decimal places
+const MinAmount = 0.001
+
+// roundAmount rounds amount to the three decimal places that are stored
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*1000) / 1000
+}
+
+// FormatAmount formats amount with at most three decimal places and no trailing zeros
+func FormatAmount(amount float64) string {
+	s := strconv.FormatFloat(roundAmount(amount), 'f', 3, 64)
+	return strings.TrimRight(strings.TrimRight(s, "0"), ".")
+}
+
+// ConvertAmount converts amount from one unit to another of the same dimension,
+// rounded to three decimal places
+func ConvertAmount(amount float64, from, to string) (float64, error) {
+	fromUnit, ok := units[from]
+	if !ok {
+		return 0, i18n.Errorf("неизвестная единица измерения: %s (доступно: %s)", from, strings.Join(Units, ", "))
+	}
+	toUnit, ok := units[to]
+	if !ok {
+		return 0, i18n.Errorf("неизвестная единица измерения: %s (доступно: %s)", to, strings.Join(Units, ", "))
+	}
+	if fromUnit.dimension != toUnit.dimension {
+		return 0, i18n.Errorf("нельзя перевести %s в %s", from, to)
+	}
+	return roundAmount(amount * fromUnit.factor / toUnit.factor), nil
+}
+
+// UnitLabel returns the translated abbreviation of a unit
+func UnitLabel(name string) string {
+	switch name {
+	case "mg":
+		return i18n.T("мг")
+	case "g":
+		return i18n.T("г")
+	case "kg":
+		return i18n.T("кг")
+	case "ml":
+		return i18n.T("мл")
+	case "l":
+		return i18n.T("л")
+	case "tsp":
+		return i18n.T("ч. л.")
+	case "tbsp":
+		return i18n.T("ст. л.")
+	case "drop":
+		return i18n.T("кап.")
+	case "piece":
+		return i18n.T("шт.")
+	}
+	return name
+}
+
+// Preparations lists the supported ways of preparing a herb
+var Preparations = []string{"infusion", "decoction", "tincture", "extract", "tea", "powder",
+	"juice", "oil", "ointment", "syrup", "compress", "bath", "fresh"}
+
+// IsPreparation reports whether preparation is one of Preparations
+func IsPreparation(preparation string) bool {
+	for _, p := range Preparations {
+		if p == preparation {
+			return true
+		}
+	}
+	return false
+}
+
+// PreparationLabel returns the translated name of a preparation method
+func PreparationLabel(preparation string) string {
+	switch preparation {
+	case "infusion":
+		return i18n.T("настой")
+	case "decoction":
+		return i18n.T("отвар")
+	case "tincture":
+		return i18n.T("настойка")
+	case "extract":
+		return i18n.T("экстракт")
+	case "tea":
+		return i18n.T("чай")
+	case "powder":
+		return i18n.T("порошок")
+	case "juice":
+		return i18n.T("свежий сок")
+	case "oil":
+		return i18n.T("масло")
+	case "ointment":
+		return i18n.T("мазь")
+	case "syrup":
+		return i18n.T("сироп")
+	case "compress":
+		return i18n.T("компресс")
+	case "bath":
+		return i18n.T("ванна")
+	case "fresh":
+		return i18n.T("в свежем виде")
+	}
+	return preparation
+}
+
+// MaxTimesPerDay is the highest supported frequency of taking a preparation
+const MaxTimesPerDay = 24
+
+// Validate checks the structured dosage fields of the usage and returns ValidationErrors
+func (u *Usage) Validate() error {
+	var v validator
+
+	if u.PlantPart != "" && !IsPlantPart(u.PlantPart) {
+		v.add("plant_part", CodeInvalid, fmt.Sprintf(i18n.T("неизвестная часть растения: %s (доступно: %s)"), u.PlantPart, strings.Join(PlantParts, ", ")))
+	}
+	if u.Preparation != "" && !IsPreparation(u.Preparation) {
+		v.add("preparation", CodeInvalid, fmt.Sprintf(i18n.T("неизвестный способ приготовления: %s (доступно: %s)"), u.Preparation, strings.Join(Preparations, ", ")))
+	}
+
+	switch {
+	case u.Amount < 0:
+		v.add("amount", CodeInvalid, i18n.T("количество не может быть отрицательным"))
+	case u.Amount > 0 && u.Amount < MinAmount:
+		v.add("amount", CodeInvalid, fmt.Sprintf(i18n.T("количество должно быть не меньше %s"), FormatAmount(MinAmount)))
+	case u.Amount > 0 && u.Unit == "":
+		v.add("unit", CodeRequired, i18n.T("для количества нужно указать единицу измерения"))
+	case u.Amount == 0 && u.Unit != "":
+		v.add("amount", CodeRequired, i18n.T("для единицы измерения нужно указать количество"))
+	}
+	if _, ok := units[u.Unit]; u.Unit != "" && !ok {
+		v.add("unit", CodeInvalid, fmt.Sprintf(i18n.T("неизвестная единица измерения: %s (доступно: %s)"), u.Unit, strings.Join(Units, ", ")))
+	}
+
+	if u.TimesPerDay < 0 || u.TimesPerDay > MaxTimesPerDay {
+		v.add("times_per_day", CodeInvalid, fmt.Sprintf(i18n.T("частота приема должна быть от 0 до %d раз в день"), MaxTimesPerDay))
+	}
+	if u.DurationDays < 0 {
+		v.add("duration_days", CodeInvalid, i18n.T("продолжительность курса не может быть отрицательной"))
+	}
+
+	return v.err()
+}
+
+// ConvertTo expresses the amount of the usage in another unit of the same dimension.
+// The usage is left unchanged if the amount would round to zero in that unit.
+func (u *Usage) ConvertTo(to string) error {
+	if u.Amount == 0 || u.Unit == to {
+		return nil
+	}
+	amount, err := ConvertAmount(u.Amount, u.Unit, to)
+	if err != nil {
+		return err
+	}
+	if amount == 0 {
+		return i18n.Errorf("количество %s %s слишком мало, чтобы выразить его в %s", FormatAmount(u.Amount), UnitLabel(u.Unit), UnitLabel(to))
+	}
+	u.Amount, u.Unit = amount, to
+	return nil
+}
+
+// AmountLabel formats the amount with the translated unit, or returns "" if no amount is set
+func (u *Usage) AmountLabel() string {
+	if u.Amount == 0 {
+		return ""
+	}
+	return FormatAmount(u.Amount) + " " + UnitLabel(u.Unit)
+}
+
+// Dosage describes the structured fields of the usage in one line, e.g.
+// "лист, настой: 10 г ×3 в день, 14 дн."
+func (u *Usage) Dosage() string {
+	var what []string
+	if u.PlantPart != "" {
+		what = append(what, PlantPartLabel(u.PlantPart))
+	}
+	if u.Preparation != "" {
+		what = append(what, PreparationLabel(u.Preparation))
+	}
+
+	var how []string
+	if amount := u.AmountLabel(); amount != "" {
+		how = append(how, amount)
+	}
+	if u.TimesPerDay > 0 {
+		how = append(how, fmt.Sprintf(i18n.T("×%d в день"), u.TimesPerDay))
+	}
+	dosage := strings.Join(how, " ")
+	if u.DurationDays > 0 {
+		if dosage != "" {
+			dosage += ", "
+		}
+		dosage += fmt.Sprintf(i18n.T("%d дн."), u.DurationDays)
+	}
+
+	switch {
+	case len(what) == 0:
+		return dosage
+	case dosage == "":
+		return strings.Join(what, ", ")
+	}
+	return strings.Join(what, ", ") + ": " + dosage
+}
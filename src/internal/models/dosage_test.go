@@ -0,0 +1,95 @@
+package models
+
+import "testing"
+
+func TestConvertAmount(t *testing.T) {
+	tests := []struct {
+		amount  float64
+		from    string
+		to      string
+		want    float64
+		wantErr bool
+	}{
+		{amount: 1, from: "g", to: "mg", want: 1000},
+		{amount: 250, from: "mg", to: "g", want: 0.25},
+		{amount: 1, from: "tbsp", to: "tsp", want: 3},
+		{amount: 2, from: "tsp", to: "ml", want: 10},
+		{amount: 20, from: "drop", to: "ml", want: 1},
+		{amount: 1, from: "ml", to: "tbsp", want: 0.067},
+		{amount: 0.1, from: "g", to: "kg", want: 0},
+		{amount: 0.7, from: "l", to: "ml", want: 700},
+		{amount: 1, from: "g", to: "ml", wantErr: true},
+		{amount: 1, from: "piece", to: "g", wantErr: true},
+		{amount: 1, from: "cup", to: "ml", wantErr: true},
+		{amount: 1, from: "ml", to: "cup", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ConvertAmount(tt.amount, tt.from, tt.to)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ConvertAmount(%v, %s, %s) error = %v, wantErr %v", tt.amount, tt.from, tt.to, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ConvertAmount(%v, %s, %s) = %v, want %v", tt.amount, tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestFormatAmount(t *testing.T) {
+	tests := []struct {
+		amount float64
+		want   string
+	}{
+		{0, "0"},
+		{1, "1"},
+		{10, "10"},
+		{0.5, "0.5"},
+		{0.25, "0.25"},
+		{0.1 + 0.2, "0.3"},
+		{1.0005, "1.001"},
+		{0.0004, "0"},
+		{1500.125, "1500.125"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatAmount(tt.amount); got != tt.want {
+			t.Errorf("FormatAmount(%v) = %q, want %q", tt.amount, got, tt.want)
+		}
+	}
+}
+
+func TestUsageConvertTo(t *testing.T) {
+	u := Usage{Amount: 1, Unit: "tbsp"}
+	if err := u.ConvertTo("ml"); err != nil || u.Amount != 15 || u.Unit != "ml" {
+		t.Errorf("ConvertTo(ml) = %v, usage %v %s, want 15 ml", err, u.Amount, u.Unit)
+	}
+
+	u = Usage{Amount: 1, Unit: "mg"}
+	if err := u.ConvertTo("kg"); err == nil || u.Amount != 1 || u.Unit != "mg" {
+		t.Errorf("ConvertTo(kg) = %v, usage %v %s, want an error and 1 mg unchanged", err, u.Amount, u.Unit)
+	}
+}
+
+func TestUsageValidateAmount(t *testing.T) {
+	tests := []struct {
+		amount  float64
+		unit    string
+		wantErr bool
+	}{
+		{amount: 0.001, unit: "g"},
+		{amount: 10, unit: "ml"},
+		{amount: 0, unit: ""},
+		{amount: 0.0009, unit: "g", wantErr: true},
+		{amount: -1, unit: "g", wantErr: true},
+		{amount: 1, unit: "", wantErr: true},
+		{amount: 0, unit: "g", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		u := Usage{Amount: tt.amount, Unit: tt.unit}
+		if err := u.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("Validate() of %v %q = %v, wantErr %v", tt.amount, tt.unit, err, tt.wantErr)
+		}
+	}
+}
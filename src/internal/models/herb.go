@@ -2,97 +2,259 @@ package models
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
+
+	"github.com/gloowl/simple_crud/src/internal/binomial"
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+	"github.com/gloowl/simple_crud/src/internal/table"
 )
 
+// displayLocation is the timezone used when timestamps are shown to the user
+var displayLocation = time.Local
+
+// SetDisplayLocation changes the timezone used to display timestamps
+func SetDisplayLocation(loc *time.Location) {
+	if loc == nil {
+		loc = time.Local
+	}
+	displayLocation = loc
+}
+
+// DisplayLocation returns the timezone used to display timestamps
+func DisplayLocation() *time.Location {
+	return displayLocation
+}
+
 // Herb - трава
 type Herb struct {
 	ID          int       `json:"id"`
 	Name        string    `json:"name"`
 	LatinName   string    `json:"latin_name"`
 	Description string    `json:"description"`
-	IsPoisonous bool      `json:"is_poisonous"`
+	IsPoisonous bool      `json:"is_poisonous"` // derived from Toxicity, see SetToxicity
 	ImagePath   string    `json:"image_path"`
 	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	CreatedBy   string    `json:"created_by"`
+	UpdatedBy   string    `json:"updated_by"`
+
+	// Parts of LatinName, filled by NormalizeLatinName
+	Genus        string `json:"genus"`
+	Species      string `json:"species"`
+	InfraRank    string `json:"infra_rank"`
+	InfraEpithet string `json:"infra_epithet"`
+	Authorship   string `json:"authorship"`
+
+	// TaxonID is the species or genus the herb belongs to
+	TaxonID *int `json:"taxon_id"`
+
+	Toxicity         ToxicityLevel `json:"toxicity"`
+	ToxicParts       []string      `json:"toxic_parts"`
+	ToxicCompounds   []string      `json:"toxic_compounds"`
+	ToxicitySymptoms []string      `json:"toxicity_symptoms"`
+
+	FloweringMonths Months `json:"flowering_months"`
+	HarvestMonths   Months `json:"harvest_months"`
 }
 
 func (h *Herb) String() string {
-	poisonous := "Нет"
-	if h.IsPoisonous {
-		poisonous = "ДА! ⚠️"
-	}
-
-	return fmt.Sprintf(`
+	s := fmt.Sprintf(i18n.T(`
 ID: %d
 Название: %s
 Латинское название: %s
 Описание: %s
-Ядовито: %s
-Изображение: %s
-Создано: %s`,
+Токсичность: %s`),
 		h.ID,
 		h.Name,
 		h.LatinName,
 		truncateString(h.Description, 100),
-		poisonous,
+		h.ToxicityLabel(),
+	)
+
+	if len(h.ToxicParts) > 0 {
+		parts := make([]string, len(h.ToxicParts))
+		for i, part := range h.ToxicParts {
+			parts[i] = PlantPartLabel(part)
+		}
+		s += fmt.Sprintf(i18n.T("\n  Ядовитые части: %s"), strings.Join(parts, ", "))
+	}
+	if len(h.ToxicCompounds) > 0 {
+		s += fmt.Sprintf(i18n.T("\n  Токсичные вещества: %s"), strings.Join(h.ToxicCompounds, ", "))
+	}
+	if len(h.ToxicitySymptoms) > 0 {
+		s += fmt.Sprintf(i18n.T("\n  Симптомы отравления: %s"), strings.Join(h.ToxicitySymptoms, ", "))
+	}
+	if len(h.FloweringMonths) > 0 {
+		s += fmt.Sprintf(i18n.T("\nЦветение: %s"), h.FloweringMonths.Label())
+	}
+	if len(h.HarvestMonths) > 0 {
+		s += fmt.Sprintf(i18n.T("\nСбор: %s"), h.HarvestMonths.Label())
+	}
+
+	return s + fmt.Sprintf(i18n.T(`
+Изображение: %s
+Создано: %s
+Обновлено: %s`),
 		h.ImagePath,
-		h.CreatedAt.Format("2006-01-02 15:04:05"),
+		withAuthor(formatTimestamp(h.CreatedAt), h.CreatedBy),
+		withAuthor(formatTimestamp(h.UpdatedAt), h.UpdatedBy),
 	)
 }
 
+// Validate checks all fields of the herb and returns ValidationErrors
+// listing every problem found, or nil if the herb is valid
 func (h *Herb) Validate() error {
-	if strings.TrimSpace(h.Name) == "" {
-		return fmt.Errorf("название травы не может быть пустым")
+	var v validator
+
+	name := strings.TrimSpace(h.Name)
+	switch {
+	case name == "":
+		v.add("name", CodeRequired, i18n.T("название травы не может быть пустым"))
+	case utf8.RuneCountInString(name) < MinNameLength:
+		v.add("name", CodeTooShort, fmt.Sprintf(i18n.T("название травы должно содержать минимум %d символа"), MinNameLength))
+	default:
+		v.length("name", h.Name, MaxNameLength, i18n.T("название травы не должно превышать %d символов"))
 	}
 
-	if len(h.Name) < 2 {
-		return fmt.Errorf("название травы должно содержать минимум 2 символа")
+	v.length("latin_name", h.LatinName, MaxLatinNameLength, i18n.T("латинское название не должно превышать %d символов"))
+	v.length("image_path", h.ImagePath, MaxImagePathLength, i18n.T("путь к изображению не должен превышать %d символов"))
+	v.length("created_by", h.CreatedBy, MaxAuthorLength, i18n.T("имя автора записи не должно превышать %d символов"))
+	v.length("updated_by", h.UpdatedBy, MaxAuthorLength, i18n.T("имя автора изменений не должно превышать %d символов"))
+
+	if h.Toxicity < ToxicityNone || h.Toxicity > ToxicityDeadly {
+		v.add("toxicity", CodeInvalid, fmt.Sprintf(i18n.T("степень токсичности должна быть одной из: %s"), strings.Join(toxicityNames, ", ")))
+	}
+	for _, part := range h.ToxicParts {
+		if !IsPlantPart(part) {
+			v.add("toxic_parts", CodeInvalid, fmt.Sprintf(i18n.T("неизвестная часть растения: %s (доступно: %s)"), part, strings.Join(PlantParts, ", ")))
+		}
+	}
+	for _, compound := range h.ToxicCompounds {
+		v.length("toxic_compounds", compound, MaxNameLength, i18n.T("название токсичного вещества не должно превышать %d символов"))
+	}
+	for _, symptom := range h.ToxicitySymptoms {
+		v.length("toxicity_symptoms", symptom, MaxNameLength, i18n.T("описание симптома не должно превышать %d символов"))
 	}
 
-	if len(h.Name) > 255 {
-		return fmt.Errorf("название травы не должно превышать 255 символов")
+	if !h.FloweringMonths.Valid() {
+		v.add("flowering_months", CodeInvalid, i18n.T("месяцы цветения должны быть от 1 до 12"))
+	}
+	if !h.HarvestMonths.Valid() {
+		v.add("harvest_months", CodeInvalid, i18n.T("месяцы сбора должны быть от 1 до 12"))
 	}
 
-	if h.LatinName != "" && len(h.LatinName) > 255 {
-		return fmt.Errorf("латинское название не должно превышать 255 символов")
+	return v.err()
+}
+
+// NormalizeLatinName rewrites LatinName with normalized capitalization and
+// whitespace and fills its parts. It returns warnings if the name is not
+// a well-formed binomial.
+func (h *Herb) NormalizeLatinName() []string {
+	name, warnings := binomial.Parse(h.LatinName)
+	h.LatinName = name.String()
+	h.Genus = name.Genus
+	h.Species = name.Species
+	h.InfraRank = name.Rank
+	h.InfraEpithet = name.Epithet
+	h.Authorship = name.Authorship
+	return warnings
+}
+
+// SetToxicity changes the toxicity level and keeps IsPoisonous in line with it
+func (h *Herb) SetToxicity(level ToxicityLevel) {
+	h.Toxicity = level
+	h.IsPoisonous = level >= PoisonousLevel
+}
+
+// SetPoisonous maps the yes/no answer of older inputs onto the toxicity level:
+// a poisonous herb below PoisonousLevel becomes severe, a non-poisonous one
+// keeps at most mild toxicity
+func (h *Herb) SetPoisonous(poisonous bool) {
+	switch {
+	case poisonous && h.Toxicity < PoisonousLevel:
+		h.SetToxicity(ToxicitySevere)
+	case !poisonous && h.Toxicity >= PoisonousLevel:
+		h.SetToxicity(ToxicityNone)
+	default:
+		h.SetToxicity(h.Toxicity)
 	}
+}
 
-	if h.ImagePath != "" && len(h.ImagePath) > 500 {
-		return fmt.Errorf("путь к изображению не должен превышать 500 символов")
+// IdentityKey returns the normalized key that identifies a herb: its latin name
+// without author citations, or its name when the latin name is empty.
+// It mirrors herb_identity_key in the database.
+func (h *Herb) IdentityKey() string {
+	if latin, _ := binomial.Parse(h.LatinName); latin.Genus != "" {
+		return "latin:" + latin.Key()
 	}
+	return "name:" + NormalizeKey(h.Name)
+}
 
-	return nil
+// NormalizeKey lowercases s and collapses all whitespace runs into single spaces.
+// strings.Fields splits on unicode.IsSpace, the same characters that
+// normalize_space removes in the database.
+func NormalizeKey(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
 }
 
 // TableHeader returns the table header for herbs
 func (h *Herb) TableHeader() string {
-	return fmt.Sprintf("%-4s %-20s %-25s %-8s %-15s",
-		"ID", "Название", "Латинское название", "Ядовито", "Создано")
+	return strings.Join([]string{
+		table.Pad("ID", 4),
+		table.Pad(i18n.T("Название"), 20),
+		table.Pad(i18n.T("Латинское название"), 25),
+		table.Pad(i18n.T("Токсичность"), 14),
+		table.Pad(i18n.T("Создано"), 11),
+		table.Pad(i18n.T("Обновлено"), 11),
+		i18n.T("Изменил"),
+	}, " ")
 }
 
 // TableRow returns a formatted table row for the herb
 func (h *Herb) TableRow() string {
-	poisonous := "Нет"
+	return strings.Join([]string{
+		table.Pad(strconv.Itoa(h.ID), 4),
+		table.Fit(h.Name, 20),
+		table.Fit(h.LatinName, 25),
+		table.Pad(h.ToxicityLabel(), 14),
+		table.Pad(h.CreatedAt.In(displayLocation).Format("2006-01-02"), 11),
+		table.Pad(h.UpdatedAt.In(displayLocation).Format("2006-01-02"), 11),
+		table.Truncate(h.UpdatedBy, 15),
+	}, " ")
+}
+
+// ToxicityLabel returns the toxicity level with its severity indicator
+func (h *Herb) ToxicityLabel() string {
+	if indicator := h.Toxicity.Indicator(); indicator != "" {
+		return indicator + " " + h.Toxicity.Label()
+	}
+	return h.Toxicity.Label()
+}
+
+// PoisonousLabel returns the short marker shown in tables
+func (h *Herb) PoisonousLabel() string {
 	if h.IsPoisonous {
-		poisonous = "ДА! ⚠️"
+		return i18n.T("ДА! ⚠️")
 	}
+	return i18n.T("Нет")
+}
 
-	return fmt.Sprintf("%-4d %-20s %-25s %-8s %-15s",
-		h.ID,
-		truncateString(h.Name, 20),
-		truncateString(h.LatinName, 25),
-		poisonous,
-		h.CreatedAt.Format("2006-01-02"),
-	)
+// formatTimestamp formats t in the display timezone, including the zone name
+func formatTimestamp(t time.Time) string {
+	return t.In(displayLocation).Format("2006-01-02 15:04:05 MST")
 }
 
-func truncateString(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
+// withAuthor appends the user who made the change, if it is known
+func withAuthor(timestamp, author string) string {
+	if author == "" {
+		return timestamp
 	}
-	if maxLen <= 3 {
-		return s[:maxLen]
-	}
-	return s[:maxLen-3] + "..."
+	return fmt.Sprintf("%s (%s)", timestamp, author)
+}
+
+func truncateString(s string, maxWidth int) string {
+	return table.Truncate(s, maxWidth)
 }
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+)
+
+// Types of herb names
+const (
+	NameVernacular = "vernacular"
+	NameSynonym    = "synonym"
+	NameAccepted   = "accepted"
+)
+
+// NameTypes lists the supported types of herb names
+var NameTypes = []string{NameVernacular, NameSynonym, NameAccepted}
+
+// languageCode matches ISO 639 language codes such as "ru", "en" or "la"
+var languageCode = regexp.MustCompile(`^[a-z]{2,3}$`)
+
+// HerbName - дополнительное название травы (народное, синоним)
+type HerbName struct {
+	ID       int    `json:"id"`
+	HerbID   int    `json:"herb_id"`
+	Name     string `json:"name"`
+	Language string `json:"language"`
+	Type     string `json:"type"`
+}
+
+// Validate checks all fields of the name and returns ValidationErrors
+func (n *HerbName) Validate() error {
+	var v validator
+
+	if strings.TrimSpace(n.Name) == "" {
+		v.add("name", CodeRequired, i18n.T("название не может быть пустым"))
+	} else {
+		v.length("name", n.Name, MaxNameLength, i18n.T("название травы не должно превышать %d символов"))
+	}
+
+	if !languageCode.MatchString(n.Language) {
+		v.add("language", CodeInvalid, i18n.T("код языка должен состоять из 2-3 латинских букв, например ru, en или la"))
+	}
+
+	valid := false
+	for _, t := range NameTypes {
+		valid = valid || n.Type == t
+	}
+	if !valid {
+		v.add("type", CodeInvalid, fmt.Sprintf(i18n.T("тип названия должен быть одним из: %s"), strings.Join(NameTypes, ", ")))
+	}
+
+	return v.err()
+}
+
+// TypeLabel returns the translated type of the name
+func (n *HerbName) TypeLabel() string {
+	switch n.Type {
+	case NameVernacular:
+		return i18n.T("народное")
+	case NameSynonym:
+		return i18n.T("синоним")
+	case NameAccepted:
+		return i18n.T("принятое")
+	}
+	return n.Type
+}
+
+// LanguageLabel returns the translated name of a language code
+func LanguageLabel(code string) string {
+	switch code {
+	case "ru":
+		return i18n.T("русский")
+	case "en":
+		return i18n.T("английский")
+	case "la":
+		return i18n.T("латынь")
+	}
+	return code
+}
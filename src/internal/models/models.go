@@ -1,10 +1,41 @@
 package models
 
-// Region - регион
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+)
+
+// Region - регион; регионы образуют иерархию: страна → область → район
 type Region struct {
 	ID          int    `json:"id"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
+	ParentID    *int   `json:"parent_id"`
+
+	// Boundary is a GeoJSON Polygon or MultiPolygon; it is loaded only by the
+	// queries that need it
+	Boundary json.RawMessage `json:"boundary,omitempty"`
+
+	// HerbCount is the number of distinct herbs linked to the region or any of its sub-regions
+	HerbCount int `json:"herb_count,omitempty"`
+}
+
+// Validate checks all fields of the region and returns ValidationErrors
+func (r *Region) Validate() error {
+	var v validator
+
+	if strings.TrimSpace(r.Name) == "" {
+		v.add("name", CodeRequired, i18n.T("название региона не может быть пустым"))
+	} else {
+		v.length("name", r.Name, MaxNameLength, i18n.T("название региона не должно превышать %d символов"))
+	}
+	if r.ParentID != nil && *r.ParentID == r.ID {
+		v.add("parent_id", CodeInvalid, i18n.T("регион не может быть вложен сам в себя"))
+	}
+
+	return v.err()
 }
 
 // UsageType - тип использования травы
@@ -20,6 +51,14 @@ type Usage struct {
 	UsageTypeID int    `json:"usage_type_id"`
 	Description string `json:"description"`
 
+	// Structured dosage; zero values mean "not specified"
+	PlantPart    string  `json:"plant_part,omitempty"`
+	Preparation  string  `json:"preparation,omitempty"`
+	Amount       float64 `json:"amount,omitempty"`
+	Unit         string  `json:"unit,omitempty"`
+	TimesPerDay  int     `json:"times_per_day,omitempty"`
+	DurationDays int     `json:"duration_days,omitempty"`
+
 	HerbName      string `json:"herb_name,omitempty"`
 	UsageTypeName string `json:"usage_type_name,omitempty"`
 }
@@ -29,13 +68,29 @@ type HerbRegion struct {
 	HerbID   int `json:"herb_id"`
 	RegionID int `json:"region_id"`
 
+	// Months in this region; nil means the months of the herb apply
+	FloweringMonths Months `json:"flowering_months,omitempty"`
+	HarvestMonths   Months `json:"harvest_months,omitempty"`
+
 	HerbName   string `json:"herb_name,omitempty"`
 	RegionName string `json:"region_name,omitempty"`
 }
 
 // Структура для ответа
 type HerbWithDetails struct {
-	Herb    Herb     `json:"herb"`
-	Regions []Region `json:"regions"`
-	Usages  []Usage  `json:"usages"`
+	Herb    Herb       `json:"herb"`
+	Regions []Region   `json:"regions"`
+	Usages  []Usage    `json:"usages"`
+	Names   []HerbName `json:"names"`
+}
+
+// LookAlike - трава, которую легко спутать с другой
+type LookAlike struct {
+	Herb  Herb   `json:"herb"`
+	Notes string `json:"notes"` // how to tell the two herbs apart
+}
+
+// IsDangerous reports whether the look-alike is poisonous while herb is not
+func (l *LookAlike) IsDangerous(herb *Herb) bool {
+	return herb.Toxicity < PoisonousLevel && l.Herb.Toxicity >= PoisonousLevel
 }
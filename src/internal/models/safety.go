@@ -0,0 +1,137 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+)
+
+// Severity grades contraindications, interactions and other safety findings
+type Severity int
+
+// Severities, from the least to the most serious
+const (
+	SeverityMinor Severity = iota + 1
+	SeverityModerate
+	SeverityMajor
+)
+
+// severityNames are the severity names used in flags and JSON
+var severityNames = []string{"minor", "moderate", "major"}
+
+// ParseSeverity parses a severity given by its name or number (1-3)
+func ParseSeverity(s string) (Severity, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for i, name := range severityNames {
+		if s == name || s == strconv.Itoa(i+1) {
+			return Severity(i + 1), nil
+		}
+	}
+	return 0, i18n.Errorf("неизвестная степень серьезности: %s (доступно: %s)", s, strings.Join(severityNames, ", "))
+}
+
+func (s Severity) String() string {
+	if s < SeverityMinor || s > SeverityMajor {
+		return strconv.Itoa(int(s))
+	}
+	return severityNames[s-1]
+}
+
+// MarshalText stores the severity by its name in JSON and YAML
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText reads a severity stored by its name or number
+func (s *Severity) UnmarshalText(text []byte) error {
+	severity, err := ParseSeverity(string(text))
+	if err != nil {
+		return err
+	}
+	*s = severity
+	return nil
+}
+
+// Label returns the translated severity with an indicator of one "!" per grade
+func (s Severity) Label() string {
+	switch s {
+	case SeverityMinor:
+		return i18n.T("! незначительная")
+	case SeverityModerate:
+		return i18n.T("!! умеренная")
+	case SeverityMajor:
+		return i18n.T("!!! серьезная")
+	}
+	return s.String()
+}
+
+// SeverityOfToxicity maps a toxicity level onto the severity of a safety finding
+func SeverityOfToxicity(level ToxicityLevel) Severity {
+	switch {
+	case level >= ToxicitySevere:
+		return SeverityMajor
+	case level == ToxicityModerate:
+		return SeverityModerate
+	default:
+		return SeverityMinor
+	}
+}
+
+// validateSeverity adds an error to v if s is not a known severity
+func validateSeverity(v *validator, s Severity) {
+	if s < SeverityMinor || s > SeverityMajor {
+		v.add("severity", CodeInvalid, fmt.Sprintf(i18n.T("степень серьезности должна быть одной из: %s"), strings.Join(severityNames, ", ")))
+	}
+}
+
+// Contraindication - противопоказание (беременность, детский возраст, заболевание)
+type Contraindication struct {
+	ID        int      `json:"id"`
+	HerbID    int      `json:"herb_id"`
+	Condition string   `json:"condition"`
+	Severity  Severity `json:"severity"`
+	Notes     string   `json:"notes"`
+
+	HerbName string `json:"herb_name,omitempty"`
+}
+
+// Validate checks all fields of the contraindication and returns ValidationErrors
+func (c *Contraindication) Validate() error {
+	var v validator
+
+	if strings.TrimSpace(c.Condition) == "" {
+		v.add("condition", CodeRequired, i18n.T("состояние не может быть пустым"))
+	} else {
+		v.length("condition", c.Condition, MaxNameLength, i18n.T("состояние не должно превышать %d символов"))
+	}
+	validateSeverity(&v, c.Severity)
+
+	return v.err()
+}
+
+// Interaction - взаимодействие травы с лекарством или группой лекарств
+type Interaction struct {
+	ID       int      `json:"id"`
+	HerbID   int      `json:"herb_id"`
+	Drug     string   `json:"drug"`
+	Severity Severity `json:"severity"`
+	Effect   string   `json:"effect"`
+
+	HerbName string `json:"herb_name,omitempty"`
+}
+
+// Validate checks all fields of the interaction and returns ValidationErrors
+func (i *Interaction) Validate() error {
+	var v validator
+
+	if strings.TrimSpace(i.Drug) == "" {
+		v.add("drug", CodeRequired, i18n.T("название лекарства не может быть пустым"))
+	} else {
+		v.length("drug", i.Drug, MaxNameLength, i18n.T("название лекарства не должно превышать %d символов"))
+	}
+	validateSeverity(&v, i.Severity)
+
+	return v.err()
+}
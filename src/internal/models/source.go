@@ -0,0 +1,277 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+)
+
+// Kinds of bibliographic sources
+const (
+	SourceBook    = "book"
+	SourceArticle = "article"
+	SourceWebsite = "website"
+	SourceOther   = "other"
+)
+
+// SourceKinds lists the supported kinds of sources
+var SourceKinds = []string{SourceBook, SourceArticle, SourceWebsite, SourceOther}
+
+// Field length limits of sources; they match the column sizes in the migrations
+const (
+	MaxSourceTitleLength = 500
+	MaxSourceFieldLength = 255
+	MaxPagesLength       = 50
+	MaxVolumeLength      = 20 // also the limit of the issue
+	MaxURLLength         = 1000
+)
+
+// doiPattern matches a DOI such as 10.1016/j.jep.2019.112345
+var doiPattern = regexp.MustCompile(`^10\.\d{4,9}/\S+$`)
+
+// Source - книга, статья или сайт, на которые ссылаются сведения о травах
+type Source struct {
+	ID        int      `json:"id"`
+	Kind      string   `json:"kind"`
+	Title     string   `json:"title"`
+	Authors   []string `json:"authors"` // "Фамилия, Имя" or an organization name
+	Year      int      `json:"year,omitempty"`
+	Container string   `json:"container,omitempty"` // journal of an article or name of a website
+	Publisher string   `json:"publisher,omitempty"`
+	Volume    string   `json:"volume,omitempty"`
+	Issue     string   `json:"issue,omitempty"`
+	Pages     string   `json:"pages,omitempty"` // page range of an article
+	DOI       string   `json:"doi,omitempty"`
+	ISBN      string   `json:"isbn,omitempty"`
+	URL       string   `json:"url,omitempty"`
+}
+
+// Normalize trims the fields and brings DOI and ISBN to their canonical form:
+// a DOI without the resolver prefix and an ISBN without hyphens and spaces
+func (s *Source) Normalize() {
+	s.Kind = strings.ToLower(strings.TrimSpace(s.Kind))
+	s.Title = strings.Join(strings.Fields(s.Title), " ")
+	s.Container = strings.TrimSpace(s.Container)
+	s.Publisher = strings.TrimSpace(s.Publisher)
+	s.Volume = strings.TrimSpace(s.Volume)
+	s.Issue = strings.TrimSpace(s.Issue)
+	s.Pages = strings.TrimSpace(s.Pages)
+	s.URL = strings.TrimSpace(s.URL)
+
+	authors := []string{}
+	for _, author := range s.Authors {
+		if author = strings.Join(strings.Fields(author), " "); author != "" {
+			authors = append(authors, author)
+		}
+	}
+	s.Authors = authors
+
+	doi := strings.TrimSpace(s.DOI)
+	for _, prefix := range []string{"https://doi.org/", "http://doi.org/", "https://dx.doi.org/", "http://dx.doi.org/", "doi:"} {
+		if len(doi) >= len(prefix) && strings.EqualFold(doi[:len(prefix)], prefix) {
+			doi = strings.TrimSpace(doi[len(prefix):])
+		}
+	}
+	s.DOI = doi
+
+	s.ISBN = strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(s.ISBN)))
+}
+
+// Validate checks all fields of the source and returns ValidationErrors
+func (s *Source) Validate() error {
+	var v validator
+
+	valid := false
+	for _, kind := range SourceKinds {
+		valid = valid || s.Kind == kind
+	}
+	if !valid {
+		v.add("kind", CodeInvalid, fmt.Sprintf(i18n.T("вид источника должен быть одним из: %s"), strings.Join(SourceKinds, ", ")))
+	}
+
+	if strings.TrimSpace(s.Title) == "" {
+		v.add("title", CodeRequired, i18n.T("название источника не может быть пустым"))
+	} else {
+		v.length("title", s.Title, MaxSourceTitleLength, i18n.T("название источника не должно превышать %d символов"))
+	}
+
+	if s.Year != 0 && (s.Year < 1000 || s.Year > time.Now().Year()+1) {
+		v.add("year", CodeInvalid, fmt.Sprintf(i18n.T("неверный год издания: %d"), s.Year))
+	}
+
+	v.length("container", s.Container, MaxSourceFieldLength, i18n.T("название журнала или сайта не должно превышать %d символов"))
+	v.length("publisher", s.Publisher, MaxSourceFieldLength, i18n.T("название издательства не должно превышать %d символов"))
+	v.length("volume", s.Volume, MaxVolumeLength, i18n.T("том не должен превышать %d символов"))
+	v.length("issue", s.Issue, MaxVolumeLength, i18n.T("номер выпуска не должен превышать %d символов"))
+	v.length("pages", s.Pages, MaxPagesLength, i18n.T("страницы не должны превышать %d символов"))
+
+	if s.DOI != "" && !doiPattern.MatchString(s.DOI) {
+		v.add("doi", CodeInvalid, fmt.Sprintf(i18n.T("неверный DOI: %s"), s.DOI))
+	} else {
+		v.length("doi", s.DOI, MaxSourceFieldLength, i18n.T("DOI не должен превышать %d символов"))
+	}
+	if s.ISBN != "" && !ValidISBN(s.ISBN) {
+		v.add("isbn", CodeInvalid, fmt.Sprintf(i18n.T("неверный ISBN: %s"), s.ISBN))
+	}
+	if s.URL != "" {
+		if u, err := url.Parse(s.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			v.add("url", CodeInvalid, fmt.Sprintf(i18n.T("неверный URL: %s"), s.URL))
+		} else {
+			v.length("url", s.URL, MaxURLLength, i18n.T("URL не должен превышать %d символов"))
+		}
+	}
+
+	return v.err()
+}
+
+// ValidISBN reports whether isbn is a normalized ISBN-10 or ISBN-13 with a correct check digit
+func ValidISBN(isbn string) bool {
+	switch len(isbn) {
+	case 10:
+		sum := 0
+		for i, r := range isbn {
+			digit := int(r - '0')
+			if i == 9 && r == 'X' {
+				digit = 10
+			} else if r < '0' || r > '9' {
+				return false
+			}
+			sum += (10 - i) * digit
+		}
+		return sum%11 == 0
+	case 13:
+		sum := 0
+		for i, r := range isbn {
+			if r < '0' || r > '9' {
+				return false
+			}
+			weight := 1
+			if i%2 == 1 {
+				weight = 3
+			}
+			sum += weight * int(r-'0')
+		}
+		return sum%10 == 0
+	}
+	return false
+}
+
+// KindLabel returns the translated kind of the source
+func (s *Source) KindLabel() string {
+	switch s.Kind {
+	case SourceBook:
+		return i18n.T("книга")
+	case SourceArticle:
+		return i18n.T("статья")
+	case SourceWebsite:
+		return i18n.T("сайт")
+	case SourceOther:
+		return i18n.T("другое")
+	}
+	return s.Kind
+}
+
+// Reference formats the source as a short bibliographic reference, e.g.
+// "Иванов, И. И. (2015) Лекарственные растения. Медицина. ISBN 9785225100001"
+func (s *Source) Reference() string {
+	var b strings.Builder
+	if len(s.Authors) > 0 {
+		b.WriteString(strings.Join(s.Authors, "; ") + " ")
+	}
+	if s.Year != 0 {
+		b.WriteString("(" + strconv.Itoa(s.Year) + ") ")
+	}
+	b.WriteString(s.Title + ".")
+
+	if s.Container != "" {
+		b.WriteString(" " + s.Container)
+		if s.Volume != "" {
+			b.WriteString(", " + s.Volume)
+		}
+		if s.Issue != "" {
+			b.WriteString("(" + s.Issue + ")")
+		}
+		if s.Pages != "" {
+			b.WriteString(", " + s.Pages)
+		}
+		b.WriteString(".")
+	}
+	if s.Publisher != "" {
+		b.WriteString(" " + s.Publisher + ".")
+	}
+
+	switch {
+	case s.DOI != "":
+		b.WriteString(" doi:" + s.DOI)
+	case s.ISBN != "":
+		b.WriteString(" ISBN " + s.ISBN)
+	case s.URL != "":
+		b.WriteString(" " + s.URL)
+	}
+	return b.String()
+}
+
+// Facts that a citation can back
+const (
+	FactHerb     = "herb"
+	FactToxicity = "toxicity"
+	FactUsage    = "usage"
+)
+
+// Facts lists the facts that can be backed by a source
+var Facts = []string{FactHerb, FactToxicity, FactUsage}
+
+// Citation - ссылка на источник, подтверждающий сведения о траве
+type Citation struct {
+	ID       int    `json:"id"`
+	SourceID int    `json:"source_id"`
+	HerbID   int    `json:"herb_id"`
+	Fact     string `json:"fact"`
+	UsageID  *int   `json:"usage_id,omitempty"`
+	Pages    string `json:"pages,omitempty"` // pages of the source that back the fact
+
+	Source *Source `json:"source,omitempty"`
+}
+
+// Validate checks all fields of the citation and returns ValidationErrors
+func (c *Citation) Validate() error {
+	var v validator
+
+	valid := false
+	for _, fact := range Facts {
+		valid = valid || c.Fact == fact
+	}
+	if !valid {
+		v.add("fact", CodeInvalid, fmt.Sprintf(i18n.T("сведения должны быть одними из: %s"), strings.Join(Facts, ", ")))
+	}
+	if c.Fact == FactUsage && c.UsageID == nil {
+		v.add("usage_id", CodeRequired, i18n.T("для ссылки на способ применения нужно указать его ID"))
+	}
+	if c.Fact != FactUsage && c.UsageID != nil {
+		v.add("usage_id", CodeInvalid, i18n.T("ID способа применения указывается только для сведений usage"))
+	}
+	v.length("pages", c.Pages, MaxPagesLength, i18n.T("страницы не должны превышать %d символов"))
+
+	return v.err()
+}
+
+// FactLabel returns the translated name of the fact backed by the citation
+func (c *Citation) FactLabel() string {
+	switch c.Fact {
+	case FactHerb:
+		return i18n.T("описание")
+	case FactToxicity:
+		return i18n.T("токсичность")
+	case FactUsage:
+		if c.UsageID != nil {
+			return fmt.Sprintf(i18n.T("применение [%d]"), *c.UsageID)
+		}
+		return i18n.T("применение")
+	}
+	return c.Fact
+}
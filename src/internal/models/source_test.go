@@ -0,0 +1,32 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSourceValidateLengths(t *testing.T) {
+	tests := []struct {
+		field  string
+		modify func(s *Source)
+	}{
+		{"volume", func(s *Source) { s.Volume = strings.Repeat("1", MaxVolumeLength+1) }},
+		{"issue", func(s *Source) { s.Issue = strings.Repeat("1", MaxVolumeLength+1) }},
+		{"doi", func(s *Source) { s.DOI = "10.1000/" + strings.Repeat("x", MaxSourceFieldLength) }},
+		{"pages", func(s *Source) { s.Pages = strings.Repeat("1", MaxPagesLength+1) }},
+	}
+
+	for _, tt := range tests {
+		s := Source{Kind: SourceArticle, Title: "Title", Volume: "12", Issue: "3", DOI: "10.1000/xyz"}
+		if err := s.Validate(); err != nil {
+			t.Fatalf("Validate() of a valid source = %v", err)
+		}
+
+		tt.modify(&s)
+		var verrs ValidationErrors
+		if err := s.Validate(); !errors.As(err, &verrs) || len(verrs) != 1 || verrs[0].Field != tt.field || verrs[0].Code != CodeTooLong {
+			t.Errorf("Validate() with a long %s = %v, want a single %s error for %s", tt.field, err, CodeTooLong, tt.field)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+)
+
+// Taxonomic ranks, from the highest to the lowest
+const (
+	RankFamily  = "family"
+	RankGenus   = "genus"
+	RankSpecies = "species"
+)
+
+// Ranks lists the supported taxonomic ranks from the highest to the lowest
+var Ranks = []string{RankFamily, RankGenus, RankSpecies}
+
+// Taxon - таксон (семейство, род или вид)
+type Taxon struct {
+	ID       int    `json:"id"`
+	Rank     string `json:"rank"`
+	Name     string `json:"name"`
+	ParentID *int   `json:"parent_id"`
+
+	// HerbCount is the number of herbs linked directly to the taxon
+	HerbCount int `json:"herb_count,omitempty"`
+}
+
+// ParentRank returns the rank a parent of rank must have, or "" for the highest rank
+func ParentRank(rank string) string {
+	for i, r := range Ranks {
+		if r == rank && i > 0 {
+			return Ranks[i-1]
+		}
+	}
+	return ""
+}
+
+// ParseRank checks that s names a supported rank and returns it in canonical form
+func ParseRank(s string) (string, error) {
+	rank := strings.ToLower(strings.TrimSpace(s))
+	for _, r := range Ranks {
+		if r == rank {
+			return rank, nil
+		}
+	}
+	return "", i18n.Errorf("неизвестный ранг: %s (доступно: %s)", s, strings.Join(Ranks, ", "))
+}
+
+// RankLabel returns the translated name of rank
+func RankLabel(rank string) string {
+	switch rank {
+	case RankFamily:
+		return i18n.T("семейство")
+	case RankGenus:
+		return i18n.T("род")
+	case RankSpecies:
+		return i18n.T("вид")
+	}
+	return rank
+}
+
+// Validate checks the name of the taxon
+func (t *Taxon) Validate() error {
+	var v validator
+
+	if strings.TrimSpace(t.Name) == "" {
+		v.add("name", CodeRequired, i18n.T("название таксона не может быть пустым"))
+	} else {
+		v.length("name", t.Name, MaxNameLength, i18n.T("название таксона не должно превышать %d символов"))
+	}
+
+	return v.err()
+}
+
+// SpeciesGenus returns the genus of the binomial name of a species taxon
+func SpeciesGenus(name string) string {
+	genus, _, _ := strings.Cut(strings.TrimSpace(name), " ")
+	return genus
+}
+
+// SpeciesName returns the name of the species taxon of herb, or "" if the
+// latin name of the herb has no specific epithet
+func SpeciesName(herb *Herb) string {
+	if herb.Genus == "" || herb.Species == "" {
+		return ""
+	}
+	return herb.Genus + " " + herb.Species
+}
@@ -0,0 +1,138 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+)
+
+// ToxicityLevel grades how dangerous a herb is
+type ToxicityLevel int
+
+// Toxicity levels, from harmless to deadly
+const (
+	ToxicityNone ToxicityLevel = iota
+	ToxicityMild
+	ToxicityModerate
+	ToxicitySevere
+	ToxicityDeadly
+)
+
+// PoisonousLevel is the lowest level at which a herb counts as poisonous.
+// It matches the is_poisonous column generated in the database.
+const PoisonousLevel = ToxicityModerate
+
+// toxicityNames are the level names used in flags, files and JSON
+var toxicityNames = []string{"none", "mild", "moderate", "severe", "deadly"}
+
+// ToxicityNames lists the names of all levels from the lowest to the highest
+func ToxicityNames() []string {
+	return append([]string(nil), toxicityNames...)
+}
+
+// ParseToxicityLevel parses a level given by its name or number (0-4)
+func ParseToxicityLevel(s string) (ToxicityLevel, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for i, name := range toxicityNames {
+		if s == name || s == strconv.Itoa(i) {
+			return ToxicityLevel(i), nil
+		}
+	}
+	return ToxicityNone, i18n.Errorf("неизвестная степень токсичности: %s (доступно: %s)", s, strings.Join(toxicityNames, ", "))
+}
+
+func (l ToxicityLevel) String() string {
+	if l < ToxicityNone || l > ToxicityDeadly {
+		return strconv.Itoa(int(l))
+	}
+	return toxicityNames[l]
+}
+
+// MarshalText stores the level by its name in JSON and YAML
+func (l ToxicityLevel) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText reads a level stored by its name or number
+func (l *ToxicityLevel) UnmarshalText(text []byte) error {
+	level, err := ParseToxicityLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*l = level
+	return nil
+}
+
+// Label returns the translated name of the level
+func (l ToxicityLevel) Label() string {
+	switch l {
+	case ToxicityNone:
+		return i18n.T("нет")
+	case ToxicityMild:
+		return i18n.T("слабая")
+	case ToxicityModerate:
+		return i18n.T("умеренная")
+	case ToxicitySevere:
+		return i18n.T("сильная")
+	case ToxicityDeadly:
+		return i18n.T("смертельная")
+	}
+	return l.String()
+}
+
+// Indicator returns the severity marker shown in tables: one "!" per level
+// and a skull for deadly herbs
+func (l ToxicityLevel) Indicator() string {
+	if l >= ToxicityDeadly {
+		return i18n.T("☠️ !!!!")
+	}
+	if l <= ToxicityNone {
+		return ""
+	}
+	return strings.Repeat("!", int(l))
+}
+
+// PlantParts lists the plant parts that can be named as toxic or used for preparations
+var PlantParts = []string{"whole", "root", "rhizome", "bulb", "stem", "bark", "leaf", "flower", "fruit", "berry", "seed", "sap"}
+
+// IsPlantPart reports whether part is one of PlantParts
+func IsPlantPart(part string) bool {
+	for _, p := range PlantParts {
+		if p == part {
+			return true
+		}
+	}
+	return false
+}
+
+// PlantPartLabel returns the translated name of a plant part
+func PlantPartLabel(part string) string {
+	switch part {
+	case "whole":
+		return i18n.T("все растение")
+	case "root":
+		return i18n.T("корень")
+	case "rhizome":
+		return i18n.T("корневище")
+	case "bulb":
+		return i18n.T("луковица")
+	case "stem":
+		return i18n.T("стебель")
+	case "bark":
+		return i18n.T("кора")
+	case "leaf":
+		return i18n.T("лист")
+	case "flower":
+		return i18n.T("цветок")
+	case "fruit":
+		return i18n.T("плод")
+	case "berry":
+		return i18n.T("ягода")
+	case "seed":
+		return i18n.T("семя")
+	case "sap":
+		return i18n.T("сок")
+	}
+	return part
+}
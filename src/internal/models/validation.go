@@ -0,0 +1,87 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Validation error codes
+const (
+	CodeRequired = "required"
+	CodeTooShort = "too_short"
+	CodeTooLong  = "too_long"
+	CodeInvalid  = "invalid"
+)
+
+// Field length limits in characters; they match the column sizes in the migrations
+const (
+	MinNameLength      = 2
+	MaxNameLength      = 255
+	MaxLatinNameLength = 255
+	MaxImagePathLength = 255
+	MaxAuthorLength    = 255
+)
+
+// ValidationError describes a problem with a single field
+type ValidationError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e ValidationError) Error() string {
+	return e.Message
+}
+
+// ValidationErrors is the list of all problems found in a record
+type ValidationErrors []ValidationError
+
+// Error returns the message of a single problem, or a list of messages
+// starting on a new line, so that it reads well after a "...: " prefix
+func (e ValidationErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Message
+	}
+	var b strings.Builder
+	for _, err := range e {
+		b.WriteString("\n  - " + err.Message)
+	}
+	return b.String()
+}
+
+// For returns the problems of the given field
+func (e ValidationErrors) For(field string) ValidationErrors {
+	var found ValidationErrors
+	for _, err := range e {
+		if err.Field == field {
+			found = append(found, err)
+		}
+	}
+	return found
+}
+
+// validator collects field errors instead of stopping at the first one
+type validator struct {
+	errs ValidationErrors
+}
+
+func (v *validator) add(field, code, message string) {
+	v.errs = append(v.errs, ValidationError{Field: field, Code: code, Message: message})
+}
+
+// length checks that value has at most max characters (not bytes);
+// message is a format with a single %d for the limit
+func (v *validator) length(field, value string, max int, message string) {
+	if utf8.RuneCountInString(value) > max {
+		v.add(field, CodeTooLong, fmt.Sprintf(message, max))
+	}
+}
+
+// err returns the collected errors, or nil if there are none
+func (v *validator) err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return v.errs
+}
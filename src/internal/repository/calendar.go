@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+	"github.com/gloowl/simple_crud/src/internal/models"
+)
+
+// seasonQuery selects herbs with their months, overridden by the months in the
+// regions $1 the herbs are linked to. The join type and the condition are
+// substituted with fmt.Sprintf.
+const seasonQuery = `
+		SELECT ` + herbColumns + `, COALESCE(links.region_name, ''),
+			COALESCE(links.region_flowering, flowering_months),
+			COALESCE(links.region_harvest, harvest_months)
+		FROM herbs
+		%s JOIN (
+			SELECT hr.herb_id AS link_herb_id, rg.name AS region_name,
+				hr.flowering_months AS region_flowering, hr.harvest_months AS region_harvest
+			FROM herbs_regions hr
+			JOIN regions rg ON rg.id = hr.region_id
+			WHERE hr.region_id = ANY($1)
+		) links ON links.link_herb_id = herbs.id
+		WHERE %s
+		ORDER BY name, herbs.id, links.region_name NULLS FIRST`
+
+type CalendarRepository struct {
+	db DBTX
+}
+
+func NewCalendarRepository(db DBTX) *CalendarRepository {
+	return &CalendarRepository{db: db}
+}
+
+// Seasons retrieves the flowering and harvest months of the given herbs, or of
+// all herbs if herbIDs is empty. A herb linked to some of regionIDs gets a row
+// per region with the months in that region; other herbs get their own months.
+func (r *CalendarRepository) Seasons(herbIDs, regionIDs []int) ([]models.HerbSeason, error) {
+	query := fmt.Sprintf(seasonQuery, "LEFT", `CARDINALITY($2::int[]) = 0 OR herbs.id = ANY($2)`)
+	return r.query(query, intArray(regionIDs), intArray(herbIDs))
+}
+
+// InSeason retrieves the herbs that flower (or can be harvested, if harvest
+// is set) in month. When regionIDs is not empty, only the herbs growing in
+// those regions are returned, with the months in the region.
+func (r *CalendarRepository) InSeason(month int, harvest bool, regionIDs []int) ([]models.HerbSeason, error) {
+	join, column := "LEFT", "flowering"
+	if len(regionIDs) > 0 {
+		join = "INNER"
+	}
+	if harvest {
+		column = "harvest"
+	}
+	condition := fmt.Sprintf(`$2 = ANY(COALESCE(links.region_%s, %s_months))`, column, column)
+	return r.query(fmt.Sprintf(seasonQuery, join, condition), intArray(regionIDs), month)
+}
+
+func (r *CalendarRepository) query(query string, args ...any) ([]models.HerbSeason, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, i18n.Errorf("ошибка получения календаря трав: %w", err)
+	}
+	defer rows.Close()
+
+	var seasons []models.HerbSeason
+	for rows.Next() {
+		season := models.HerbSeason{}
+		err := scanHerb(withExtra(rows, &season.Region,
+			monthArray(&season.Flowering), monthArray(&season.Harvest)), &season.Herb)
+		if err != nil {
+			return nil, i18n.Errorf("ошибка сканирования календаря трав: %w", err)
+		}
+		seasons = append(seasons, season)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, i18n.Errorf("ошибка итерации по календарю трав: %w", err)
+	}
+
+	return seasons, nil
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+	"github.com/gloowl/simple_crud/src/internal/models"
+	"github.com/lib/pq"
+)
+
+// ErrHerbExists is returned when a herb with the same latin name
+// (or the same name, if it has no latin name) is already stored
+var ErrHerbExists = i18n.NewError("такая трава уже существует")
+
+// uniqueViolation is the PostgreSQL error code for unique constraint violations
+const uniqueViolation = "23505"
+
+// checkViolation is the PostgreSQL error code for check constraint violations
+const checkViolation = "23514"
+
+// isUniqueViolation reports whether err was caused by the given unique index
+func isUniqueViolation(err error, constraint string) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	return pqErr.Code == uniqueViolation && pqErr.Constraint == constraint
+}
+
+// isCheckViolation reports whether err was caused by the given check constraint
+func isCheckViolation(err error, constraint string) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	return pqErr.Code == checkViolation && pqErr.Constraint == constraint
+}
+
+// herbExistsError describes which field of herb collides with an existing record
+func herbExistsError(herb *models.Herb) error {
+	if herb.LatinName != "" {
+		return i18n.Errorf("%w: латинское название «%s» уже занято", ErrHerbExists, herb.LatinName)
+	}
+	return i18n.Errorf("%w: название «%s» уже занято (укажите латинское название, чтобы различать травы)", ErrHerbExists, herb.Name)
+}
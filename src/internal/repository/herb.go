@@ -2,55 +2,155 @@ package repository
 
 import (
 	"database/sql"
-	"fmt"
+	"github.com/gloowl/simple_crud/src/internal/i18n"
 	"github.com/gloowl/simple_crud/src/internal/models"
+
+	"github.com/lib/pq"
 )
 
+// herbColumns lists the herbs columns in the order expected by scanHerb
+const herbColumns = `id, name, latin_name, description, is_poisonous, image_path,
+		created_at, updated_at, created_by, updated_by,
+		genus, species, infra_rank, infra_epithet, authorship, taxon_id,
+		toxicity_level, toxic_parts, toxic_compounds, toxicity_symptoms,
+		flowering_months, harvest_months`
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanHerb reads a single herb selected with herbColumns
+func scanHerb(row rowScanner, herb *models.Herb) error {
+	err := row.Scan(&herb.ID, &herb.Name, &herb.LatinName, &herb.Description,
+		&herb.IsPoisonous, &herb.ImagePath, &herb.CreatedAt, &herb.UpdatedAt,
+		&herb.CreatedBy, &herb.UpdatedBy,
+		&herb.Genus, &herb.Species, &herb.InfraRank, &herb.InfraEpithet, &herb.Authorship, &herb.TaxonID,
+		&herb.Toxicity, pq.Array(&herb.ToxicParts), pq.Array(&herb.ToxicCompounds), pq.Array(&herb.ToxicitySymptoms),
+		monthArray(&herb.FloweringMonths), monthArray(&herb.HarvestMonths))
+	herb.SetToxicity(herb.Toxicity)
+	return err
+}
+
+// monthScanner reads a smallint[] column of months; NULL is read as nil
+type monthScanner struct {
+	months *models.Months
+}
+
+func (s monthScanner) Scan(src any) error {
+	var values pq.Int64Array
+	if err := values.Scan(src); err != nil {
+		return err
+	}
+	if values == nil {
+		*s.months = nil
+		return nil
+	}
+	months := make(models.Months, len(values))
+	for i, value := range values {
+		months[i] = int(value)
+	}
+	*s.months = months
+	return nil
+}
+
+// monthArray lets a smallint[] column of months be scanned into months
+func monthArray(months *models.Months) sql.Scanner {
+	return monthScanner{months: months}
+}
+
+// extraScanner reads additional columns selected after herbColumns
+type extraScanner struct {
+	row   rowScanner
+	extra []any
+}
+
+func (s extraScanner) Scan(dest ...any) error {
+	return s.row.Scan(append(dest, s.extra...)...)
+}
+
+// withExtra lets scanHerb read a row that has more columns after herbColumns
+func withExtra(row rowScanner, extra ...any) rowScanner {
+	return extraScanner{row: row, extra: extra}
+}
+
+// scanHerbs reads all rows selected with herbColumns
+func scanHerbs(rows *sql.Rows) ([]models.Herb, error) {
+	var herbs []models.Herb
+	for rows.Next() {
+		herb := models.Herb{}
+		if err := scanHerb(rows, &herb); err != nil {
+			return nil, i18n.Errorf("ошибка сканирования травы: %w", err)
+		}
+		herbs = append(herbs, herb)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, i18n.Errorf("ошибка итерации по травам: %w", err)
+	}
+
+	return herbs, nil
+}
+
 type HerbRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
-func NewHerbRepository(db *sql.DB) *HerbRepository {
+func NewHerbRepository(db DBTX) *HerbRepository {
 	return &HerbRepository{db: db}
 }
 
-// Create adds a new herb to the database
+// Create adds a new herb to the database. The latin name is normalized
+// and split into its parts before saving, and the herb is linked to its taxon.
 func (r *HerbRepository) Create(herb *models.Herb) error {
+	herb.NormalizeLatinName()
+	herb.SetToxicity(herb.Toxicity)
+	herb.FloweringMonths = herb.FloweringMonths.Normalize()
+	herb.HarvestMonths = herb.HarvestMonths.Normalize()
 	if err := herb.Validate(); err != nil {
 		return err
 	}
 
-	query := `
-		INSERT INTO herbs (name, latin_name, description, is_poisonous, image_path) 
-		VALUES ($1, $2, $3, $4, $5) 
-		RETURNING id, created_at`
+	// The author of a new record is also its last editor
+	herb.UpdatedBy = herb.CreatedBy
 
-	err := r.db.QueryRow(query, herb.Name, herb.LatinName, herb.Description, herb.IsPoisonous, herb.ImagePath).Scan(&herb.ID, &herb.CreatedAt)
+	query := `
+		INSERT INTO herbs (name, latin_name, description, toxicity_level, image_path, created_by, updated_by,
+		                   genus, species, infra_rank, infra_epithet, authorship,
+		                   toxic_parts, toxic_compounds, toxicity_symptoms, flowering_months, harvest_months) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17) 
+		RETURNING id, created_at, updated_at`
+
+	err := r.db.QueryRow(query, herb.Name, herb.LatinName, herb.Description, herb.Toxicity, herb.ImagePath,
+		herb.CreatedBy, herb.UpdatedBy,
+		herb.Genus, herb.Species, herb.InfraRank, herb.InfraEpithet, herb.Authorship,
+		textArray(herb.ToxicParts), textArray(herb.ToxicCompounds), textArray(herb.ToxicitySymptoms),
+		intArray(herb.FloweringMonths), intArray(herb.HarvestMonths)).Scan(&herb.ID, &herb.CreatedAt, &herb.UpdatedAt)
 
 	if err != nil {
-		return fmt.Errorf("ошибка создания травы: %v", err)
+		if isUniqueViolation(err, "herbs_identity_key") {
+			return herbExistsError(herb)
+		}
+		return i18n.Errorf("ошибка создания травы: %w", err)
 	}
-	return nil
+	return NewTaxonRepository(r.db).LinkHerb(herb)
 }
 
 // GetByID retrieves a herb by its ID
 func (r *HerbRepository) GetByID(id int) (*models.Herb, error) {
 	herb := &models.Herb{}
 	query := `
-		SELECT id, name, latin_name, description, is_poisonous, image_path, created_at
+		SELECT ` + herbColumns + `
 		FROM herbs 
 		WHERE id = $1`
 
-	err := r.db.QueryRow(query, id).Scan(
-		&herb.ID, &herb.Name, &herb.LatinName, &herb.Description,
-		&herb.IsPoisonous, &herb.ImagePath, &herb.CreatedAt,
-	)
+	err := scanHerb(r.db.QueryRow(query, id), herb)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("трава с ID %d не найдена", id)
+			return nil, i18n.Errorf("трава с ID %d не найдена", id)
 		}
-		return nil, fmt.Errorf("ошибка получения травы: %v", err)
+		return nil, i18n.Errorf("ошибка получения травы: %w", err)
 	}
 	return herb, nil
 }
@@ -58,63 +158,58 @@ func (r *HerbRepository) GetByID(id int) (*models.Herb, error) {
 // GetAll retrieves all herbs
 func (r *HerbRepository) GetAll() ([]models.Herb, error) {
 	query := `
-		SELECT id, name, latin_name, description, is_poisonous, image_path, created_at
+		SELECT ` + herbColumns + `
 		FROM herbs 
 		ORDER BY name`
 
 	rows, err := r.db.Query(query)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка получения списка трав: %v", err)
+		return nil, i18n.Errorf("ошибка получения списка трав: %w", err)
 	}
 	defer rows.Close()
 
-	var herbs []models.Herb
-	for rows.Next() {
-		herb := models.Herb{}
-		err := rows.Scan(&herb.ID, &herb.Name, &herb.LatinName, &herb.Description,
-			&herb.IsPoisonous, &herb.ImagePath, &herb.CreatedAt)
-		if err != nil {
-			return nil, fmt.Errorf("ошибка сканирования травы: %v", err)
-		}
-		herbs = append(herbs, herb)
-	}
-
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("ошибка итерации по травам: %v", err)
-	}
-
-	return herbs, nil
+	return scanHerbs(rows)
 }
 
-// Update modifies an existing herb
+// Update modifies an existing herb. The latin name is normalized
+// and split into its parts before saving, and the herb is relinked to its taxon.
 func (r *HerbRepository) Update(herb *models.Herb) error {
+	herb.NormalizeLatinName()
+	herb.SetToxicity(herb.Toxicity)
+	herb.FloweringMonths = herb.FloweringMonths.Normalize()
+	herb.HarvestMonths = herb.HarvestMonths.Normalize()
 	if err := herb.Validate(); err != nil {
 		return err
 	}
 
+	// updated_at is maintained by the herbs_set_updated_at trigger
 	query := `
 		UPDATE herbs 
 		SET name = $2, latin_name = $3, description = $4, 
-		    is_poisonous = $5, image_path = $6
-		WHERE id = $1`
-
-	result, err := r.db.Exec(query, herb.ID, herb.Name, herb.LatinName,
-		herb.Description, herb.IsPoisonous, herb.ImagePath)
+		    toxicity_level = $5, image_path = $6, updated_by = $7,
+		    genus = $8, species = $9, infra_rank = $10, infra_epithet = $11, authorship = $12,
+		    toxic_parts = $13, toxic_compounds = $14, toxicity_symptoms = $15,
+		    flowering_months = $16, harvest_months = $17
+		WHERE id = $1
+		RETURNING updated_at`
+
+	err := r.db.QueryRow(query, herb.ID, herb.Name, herb.LatinName,
+		herb.Description, herb.Toxicity, herb.ImagePath, herb.UpdatedBy,
+		herb.Genus, herb.Species, herb.InfraRank, herb.InfraEpithet, herb.Authorship,
+		textArray(herb.ToxicParts), textArray(herb.ToxicCompounds), textArray(herb.ToxicitySymptoms),
+		intArray(herb.FloweringMonths), intArray(herb.HarvestMonths)).Scan(&herb.UpdatedAt)
 
 	if err != nil {
-		return fmt.Errorf("ошибка обновления травы: %v", err)
-	}
-
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("ошибка получения количества затронутых строк: %v", err)
-	}
-
-	if rowsAffected == 0 {
-		return fmt.Errorf("трава с ID %d не найдена", herb.ID)
+		if err == sql.ErrNoRows {
+			return i18n.Errorf("трава с ID %d не найдена", herb.ID)
+		}
+		if isUniqueViolation(err, "herbs_identity_key") {
+			return herbExistsError(herb)
+		}
+		return i18n.Errorf("ошибка обновления травы: %w", err)
 	}
 
-	return nil
+	return NewTaxonRepository(r.db).LinkHerb(herb)
 }
 
 // Delete removes a herb from the database
@@ -123,73 +218,168 @@ func (r *HerbRepository) Delete(id int) error {
 
 	result, err := r.db.Exec(query, id)
 	if err != nil {
-		return fmt.Errorf("ошибка удаления травы: %v", err)
+		return i18n.Errorf("ошибка удаления травы: %w", err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("ошибка получения количества затронутых строк: %v", err)
+		return i18n.Errorf("ошибка получения количества затронутых строк: %w", err)
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("трава с ID %d не найдена", id)
+		return i18n.Errorf("трава с ID %d не найдена", id)
 	}
 
 	return nil
 }
 
-// Search finds herbs by name (case-insensitive partial match)
+// Search finds herbs by name, latin name or any of their alternative names
+// (case-insensitive partial match)
 func (r *HerbRepository) Search(name string) ([]models.Herb, error) {
 	query := `
-		SELECT id, name, latin_name, description, is_poisonous, image_path, created_at
+		SELECT ` + herbColumns + `
 		FROM herbs 
 		WHERE LOWER(name) LIKE LOWER($1) OR LOWER(latin_name) LIKE LOWER($1)
+		   OR EXISTS (SELECT 1 FROM herb_names n WHERE n.herb_id = herbs.id AND LOWER(n.name) LIKE LOWER($1))
 		ORDER BY name`
 
 	rows, err := r.db.Query(query, "%"+name+"%")
 	if err != nil {
-		return nil, fmt.Errorf("ошибка поиска трав: %v", err)
+		return nil, i18n.Errorf("ошибка поиска трав: %w", err)
 	}
 	defer rows.Close()
 
-	var herbs []models.Herb
-	for rows.Next() {
-		herb := models.Herb{}
-		err := rows.Scan(&herb.ID, &herb.Name, &herb.LatinName, &herb.Description,
-			&herb.IsPoisonous, &herb.ImagePath, &herb.CreatedAt)
-		if err != nil {
-			return nil, fmt.Errorf("ошибка сканирования травы: %v", err)
-		}
-		herbs = append(herbs, herb)
+	return scanHerbs(rows)
+}
+
+// GetPoisonous retrieves all herbs with at least the given toxicity level,
+// the most dangerous first
+func (r *HerbRepository) GetPoisonous(minLevel models.ToxicityLevel) ([]models.Herb, error) {
+	query := `
+		SELECT ` + herbColumns + `
+		FROM herbs 
+		WHERE toxicity_level >= $1
+		ORDER BY toxicity_level DESC, name`
+
+	rows, err := r.db.Query(query, minLevel)
+	if err != nil {
+		return nil, i18n.Errorf("ошибка получения ядовитых трав: %w", err)
 	}
+	defer rows.Close()
 
-	return herbs, rows.Err()
+	return scanHerbs(rows)
 }
 
-// GetPoisonous retrieves all poisonous herbs
-func (r *HerbRepository) GetPoisonous() ([]models.Herb, error) {
+// Find retrieves the herbs matching filter, ordered by name
+func (r *HerbRepository) Find(filter HerbFilter) ([]models.Herb, error) {
+	where, args := filter.where()
 	query := `
-		SELECT id, name, latin_name, description, is_poisonous, image_path, created_at
+		SELECT ` + herbColumns + `
 		FROM herbs 
-		WHERE is_poisonous = true
+		` + where + `
 		ORDER BY name`
 
-	rows, err := r.db.Query(query)
+	rows, err := r.db.Query(query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка получения ядовитых трав: %v", err)
+		return nil, i18n.Errorf("ошибка получения списка трав: %w", err)
 	}
 	defer rows.Close()
 
-	var herbs []models.Herb
-	for rows.Next() {
-		herb := models.Herb{}
-		err := rows.Scan(&herb.ID, &herb.Name, &herb.LatinName, &herb.Description,
-			&herb.IsPoisonous, &herb.ImagePath, &herb.CreatedAt)
-		if err != nil {
-			return nil, fmt.Errorf("ошибка сканирования травы: %v", err)
-		}
-		herbs = append(herbs, herb)
+	return scanHerbs(rows)
+}
+
+// DeleteMany removes all herbs with the given IDs. It fails without deleting
+// anything (when run in a unit of work) if any of the herbs does not exist.
+func (r *HerbRepository) DeleteMany(ids []int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	result, err := r.db.Exec(`DELETE FROM herbs WHERE id = ANY($1)`, intArray(ids))
+	if err != nil {
+		return i18n.Errorf("ошибка удаления трав: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return i18n.Errorf("ошибка получения количества затронутых строк: %w", err)
+	}
+
+	if rowsAffected != int64(len(ids)) {
+		return i18n.Errorf("удалено %d трав из %d: часть записей уже не существует", rowsAffected, len(ids))
 	}
 
-	return herbs, rows.Err()
+	return nil
+}
+
+// MoveLinks reassigns the regions, usages, alternative names, look-alikes,
+// contraindications, interactions and citations of herb fromID to herb toID.
+// Links that toID already has are left as they are; the remaining ones of
+// fromID are removed when that herb is deleted.
+func (r *HerbRepository) MoveLinks(fromID, toID int) error {
+	_, err := r.db.Exec(`
+		INSERT INTO herbs_regions (herb_id, region_id, flowering_months, harvest_months)
+		SELECT $1, region_id, flowering_months, harvest_months FROM herbs_regions WHERE herb_id = $2
+		ON CONFLICT DO NOTHING`, toID, fromID)
+	if err != nil {
+		return i18n.Errorf("ошибка переноса регионов: %w", err)
+	}
+
+	_, err = r.db.Exec(`UPDATE usages SET herb_id = $1 WHERE herb_id = $2`, toID, fromID)
+	if err != nil {
+		return i18n.Errorf("ошибка переноса способов применения: %w", err)
+	}
+
+	_, err = r.db.Exec(`
+		UPDATE herb_names SET herb_id = $1
+		WHERE herb_id = $2 AND NOT EXISTS (
+			SELECT 1 FROM herb_names n
+			WHERE n.herb_id = $1 AND n.language = herb_names.language AND LOWER(n.name) = LOWER(herb_names.name))`, toID, fromID)
+	if err != nil {
+		return i18n.Errorf("ошибка переноса названий: %w", err)
+	}
+
+	_, err = r.db.Exec(`
+		INSERT INTO herb_lookalikes (herb_id, lookalike_id, notes)
+		SELECT LEAST($1, other), GREATEST($1, other), notes
+		FROM (
+			SELECT CASE WHEN herb_id = $2 THEN lookalike_id ELSE herb_id END AS other, notes
+			FROM herb_lookalikes
+			WHERE herb_id = $2 OR lookalike_id = $2
+		) moved
+		WHERE other <> $1
+		ON CONFLICT DO NOTHING`, toID, fromID)
+	if err != nil {
+		return i18n.Errorf("ошибка переноса похожих трав: %w", err)
+	}
+
+	_, err = r.db.Exec(`
+		UPDATE contraindications SET herb_id = $1
+		WHERE herb_id = $2 AND NOT EXISTS (
+			SELECT 1 FROM contraindications c
+			WHERE c.herb_id = $1 AND LOWER(c.condition) = LOWER(contraindications.condition))`, toID, fromID)
+	if err != nil {
+		return i18n.Errorf("ошибка переноса противопоказаний: %w", err)
+	}
+
+	_, err = r.db.Exec(`
+		UPDATE interactions SET herb_id = $1
+		WHERE herb_id = $2 AND NOT EXISTS (
+			SELECT 1 FROM interactions i
+			WHERE i.herb_id = $1 AND LOWER(i.drug) = LOWER(interactions.drug))`, toID, fromID)
+	if err != nil {
+		return i18n.Errorf("ошибка переноса взаимодействий: %w", err)
+	}
+
+	_, err = r.db.Exec(`
+		UPDATE citations SET herb_id = $1
+		WHERE herb_id = $2 AND NOT EXISTS (
+			SELECT 1 FROM citations c
+			WHERE c.herb_id = $1 AND c.fact = citations.fact AND c.source_id = citations.source_id
+			  AND COALESCE(c.usage_id, 0) = COALESCE(citations.usage_id, 0))`, toID, fromID)
+	if err != nil {
+		return i18n.Errorf("ошибка переноса ссылок на источники: %w", err)
+	}
+
+	return nil
 }
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gloowl/simple_crud/src/internal/models"
+	"github.com/lib/pq"
+)
+
+// HerbFilter selects herbs by their attributes. Zero-valued fields are not applied.
+type HerbFilter struct {
+	IDs           []int
+	Poisonous     *bool
+	MinToxicity   models.ToxicityLevel
+	CreatedBefore time.Time
+	CreatedAfter  time.Time
+	Genus         string
+	Family        string
+	// TaxonID selects the herbs of a taxon of any rank and of its descendants
+	TaxonID int
+	// RegionID selects the herbs growing in a region or any of its sub-regions
+	RegionID int
+}
+
+// IsEmpty reports whether the filter matches every herb
+func (f HerbFilter) IsEmpty() bool {
+	return len(f.IDs) == 0 && f.Poisonous == nil && f.MinToxicity == models.ToxicityNone && f.CreatedBefore.IsZero() && f.CreatedAfter.IsZero() &&
+		f.Genus == "" && f.Family == "" && f.TaxonID == 0 && f.RegionID == 0
+}
+
+// where builds the WHERE clause for the filter together with its arguments
+func (f HerbFilter) where() (string, []any) {
+	var (
+		conditions []string
+		args       []any
+	)
+
+	add := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if len(f.IDs) > 0 {
+		add("id = ANY($%d)", intArray(f.IDs))
+	}
+	if f.Poisonous != nil {
+		add("is_poisonous = $%d", *f.Poisonous)
+	}
+	if f.MinToxicity > models.ToxicityNone {
+		add("toxicity_level >= $%d", f.MinToxicity)
+	}
+	if !f.CreatedBefore.IsZero() {
+		add("created_at < $%d", f.CreatedBefore)
+	}
+	if !f.CreatedAfter.IsZero() {
+		add("created_at >= $%d", f.CreatedAfter)
+	}
+	if f.Genus != "" {
+		add("LOWER(genus) = LOWER($%d)", f.Genus)
+	}
+	if f.Family != "" {
+		add("taxon_id IN ("+fmt.Sprintf(taxonSubtree, "rank = 'family' AND LOWER(name) = LOWER($%d)")+")", f.Family)
+	}
+	if f.TaxonID != 0 {
+		add("taxon_id IN ("+fmt.Sprintf(taxonSubtree, "id = $%d")+")", f.TaxonID)
+	}
+	if f.RegionID != 0 {
+		add("id IN (SELECT herb_id FROM herbs_regions WHERE region_id IN ("+fmt.Sprintf(regionSubtree, "id = $%d")+"))", f.RegionID)
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// intArray converts ids into a PostgreSQL integer array parameter
+func intArray(ids []int) any {
+	values := make([]int64, len(ids))
+	for i, id := range ids {
+		values[i] = int64(id)
+	}
+	return pq.Array(values)
+}
+
+// textArray converts values into a PostgreSQL text array parameter; nil becomes an empty array
+func textArray(values []string) any {
+	if values == nil {
+		values = []string{}
+	}
+	return pq.Array(values)
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+	"github.com/gloowl/simple_crud/src/internal/models"
+)
+
+type HerbNameRepository struct {
+	db DBTX
+}
+
+func NewHerbNameRepository(db DBTX) *HerbNameRepository {
+	return &HerbNameRepository{db: db}
+}
+
+// Create adds an alternative name of a herb
+func (r *HerbNameRepository) Create(name *models.HerbName) error {
+	if err := name.Validate(); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO herb_names (herb_id, name, language, type)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`
+
+	err := r.db.QueryRow(query, name.HerbID, name.Name, name.Language, name.Type).Scan(&name.ID)
+	if err != nil {
+		if isUniqueViolation(err, "herb_names_herb_language_name") {
+			return i18n.Errorf("у травы уже есть название «%s» (%s)", name.Name, name.Language)
+		}
+		return i18n.Errorf("ошибка добавления названия: %w", err)
+	}
+	return nil
+}
+
+// GetByHerb retrieves the alternative names of a herb ordered by language and type
+func (r *HerbNameRepository) GetByHerb(herbID int) ([]models.HerbName, error) {
+	query := `
+		SELECT id, herb_id, name, language, type
+		FROM herb_names
+		WHERE herb_id = $1
+		ORDER BY language, type, name`
+
+	rows, err := r.db.Query(query, herbID)
+	if err != nil {
+		return nil, i18n.Errorf("ошибка получения названий травы: %w", err)
+	}
+	defer rows.Close()
+
+	var names []models.HerbName
+	for rows.Next() {
+		name := models.HerbName{}
+		if err := rows.Scan(&name.ID, &name.HerbID, &name.Name, &name.Language, &name.Type); err != nil {
+			return nil, i18n.Errorf("ошибка сканирования названия: %w", err)
+		}
+		names = append(names, name)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, i18n.Errorf("ошибка итерации по названиям: %w", err)
+	}
+
+	return names, nil
+}
+
+// Delete removes an alternative name by its ID
+func (r *HerbNameRepository) Delete(id int) error {
+	result, err := r.db.Exec(`DELETE FROM herb_names WHERE id = $1`, id)
+	if err != nil {
+		return i18n.Errorf("ошибка удаления названия: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return i18n.Errorf("ошибка получения количества затронутых строк: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return i18n.Errorf("название с ID %d не найдено", id)
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+	"github.com/gloowl/simple_crud/src/internal/models"
+)
+
+type LookAlikeRepository struct {
+	db DBTX
+}
+
+func NewLookAlikeRepository(db DBTX) *LookAlikeRepository {
+	return &LookAlikeRepository{db: db}
+}
+
+// orderedPair returns the IDs in the order they are stored in herb_lookalikes
+func orderedPair(a, b int) (int, int) {
+	if a > b {
+		return b, a
+	}
+	return a, b
+}
+
+// Link records that two herbs look alike, replacing the notes of an existing link
+func (r *LookAlikeRepository) Link(herbID, otherID int, notes string) error {
+	if herbID == otherID {
+		return i18n.Errorf("трава не может быть похожа сама на себя")
+	}
+
+	first, second := orderedPair(herbID, otherID)
+	query := `
+		INSERT INTO herb_lookalikes (herb_id, lookalike_id, notes)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (herb_id, lookalike_id) DO UPDATE SET notes = EXCLUDED.notes`
+
+	if _, err := r.db.Exec(query, first, second, notes); err != nil {
+		return i18n.Errorf("ошибка сохранения похожей травы: %w", err)
+	}
+	return nil
+}
+
+// Unlink removes the look-alike relation between two herbs
+func (r *LookAlikeRepository) Unlink(herbID, otherID int) error {
+	first, second := orderedPair(herbID, otherID)
+
+	result, err := r.db.Exec(`DELETE FROM herb_lookalikes WHERE herb_id = $1 AND lookalike_id = $2`, first, second)
+	if err != nil {
+		return i18n.Errorf("ошибка удаления похожей травы: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return i18n.Errorf("ошибка получения количества затронутых строк: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return i18n.Errorf("травы с ID %d и %d не отмечены как похожие", herbID, otherID)
+	}
+
+	return nil
+}
+
+// GetByHerb retrieves the herbs that look like the given one, the most toxic first
+func (r *LookAlikeRepository) GetByHerb(herbID int) ([]models.LookAlike, error) {
+	query := `
+		SELECT ` + herbColumns + `, l.notes
+		FROM herb_lookalikes l
+		JOIN herbs ON herbs.id = CASE WHEN l.herb_id = $1 THEN l.lookalike_id ELSE l.herb_id END
+		WHERE l.herb_id = $1 OR l.lookalike_id = $1
+		ORDER BY toxicity_level DESC, name`
+
+	rows, err := r.db.Query(query, herbID)
+	if err != nil {
+		return nil, i18n.Errorf("ошибка получения похожих трав: %w", err)
+	}
+	defer rows.Close()
+
+	var lookalikes []models.LookAlike
+	for rows.Next() {
+		lookalike := models.LookAlike{}
+		if err := scanHerb(withExtra(rows, &lookalike.Notes), &lookalike.Herb); err != nil {
+			return nil, i18n.Errorf("ошибка сканирования похожей травы: %w", err)
+		}
+		lookalikes = append(lookalikes, lookalike)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, i18n.Errorf("ошибка итерации по похожим травам: %w", err)
+	}
+
+	return lookalikes, nil
+}
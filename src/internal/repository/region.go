@@ -0,0 +1,378 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+	"github.com/gloowl/simple_crud/src/internal/models"
+)
+
+// regionColumns are the columns read by scanRegions, prefixed with the regions alias r
+const regionColumns = `r.id, r.name, COALESCE(r.description, ''), r.parent_id`
+
+// regionSubtree selects the IDs of a region and all of its sub-regions;
+// the condition on the root region is substituted with fmt.Sprintf
+const regionSubtree = `
+		WITH RECURSIVE subtree AS (
+			SELECT id FROM regions WHERE %s
+			UNION
+			SELECT r.id FROM regions r JOIN subtree s ON r.parent_id = s.id
+		)
+		SELECT id FROM subtree`
+
+type RegionRepository struct {
+	db DBTX
+}
+
+func NewRegionRepository(db DBTX) *RegionRepository {
+	return &RegionRepository{db: db}
+}
+
+// GetAll retrieves all regions
+func (r *RegionRepository) GetAll() ([]models.Region, error) {
+	query := `
+		SELECT ` + regionColumns + `
+		FROM regions r
+		ORDER BY r.name`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, i18n.Errorf("ошибка получения списка регионов: %w", err)
+	}
+	defer rows.Close()
+
+	return scanRegions(rows)
+}
+
+// GetTree retrieves all regions together with the number of distinct herbs
+// linked to each region or any of its sub-regions
+func (r *RegionRepository) GetTree() ([]models.Region, error) {
+	query := `
+		WITH RECURSIVE closure AS (
+			SELECT id AS ancestor_id, id AS region_id FROM regions
+			UNION
+			SELECT c.ancestor_id, r.id FROM regions r JOIN closure c ON r.parent_id = c.region_id
+		)
+		SELECT ` + regionColumns + `, COUNT(DISTINCT hr.herb_id)
+		FROM regions r
+		JOIN closure c ON c.ancestor_id = r.id
+		LEFT JOIN herbs_regions hr ON hr.region_id = c.region_id
+		GROUP BY r.id
+		ORDER BY r.name`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, i18n.Errorf("ошибка получения списка регионов: %w", err)
+	}
+	defer rows.Close()
+
+	var regions []models.Region
+	for rows.Next() {
+		region := models.Region{}
+		if err := scanRegion(withExtra(rows, &region.HerbCount), &region); err != nil {
+			return nil, i18n.Errorf("ошибка сканирования региона: %w", err)
+		}
+		regions = append(regions, region)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, i18n.Errorf("ошибка итерации по регионам: %w", err)
+	}
+
+	return regions, nil
+}
+
+// GetByID retrieves a region by its ID
+func (r *RegionRepository) GetByID(id int) (*models.Region, error) {
+	region := &models.Region{}
+	query := `SELECT ` + regionColumns + ` FROM regions r WHERE r.id = $1`
+
+	if err := scanRegion(r.db.QueryRow(query, id), region); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, i18n.Errorf("регион с ID %d не найден", id)
+		}
+		return nil, i18n.Errorf("ошибка получения региона: %w", err)
+	}
+	return region, nil
+}
+
+// Subtree returns the IDs of a region and all of its sub-regions
+func (r *RegionRepository) Subtree(id int) ([]int, error) {
+	rows, err := r.db.Query(fmt.Sprintf(regionSubtree, "id = $1"), id)
+	if err != nil {
+		return nil, i18n.Errorf("ошибка получения подрегионов: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var subID int
+		if err := rows.Scan(&subID); err != nil {
+			return nil, i18n.Errorf("ошибка сканирования региона: %w", err)
+		}
+		ids = append(ids, subID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, i18n.Errorf("ошибка итерации по регионам: %w", err)
+	}
+
+	return ids, nil
+}
+
+// GetWithBoundaries retrieves the regions that have a boundary, loading it
+func (r *RegionRepository) GetWithBoundaries() ([]models.Region, error) {
+	query := `
+		SELECT ` + regionColumns + `, r.boundary::text
+		FROM regions r
+		WHERE r.boundary IS NOT NULL
+		ORDER BY r.name`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, i18n.Errorf("ошибка получения границ регионов: %w", err)
+	}
+	defer rows.Close()
+
+	var regions []models.Region
+	for rows.Next() {
+		region := models.Region{}
+		if err := scanRegion(withExtra(rows, (*[]byte)(&region.Boundary)), &region); err != nil {
+			return nil, i18n.Errorf("ошибка сканирования региона: %w", err)
+		}
+		regions = append(regions, region)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, i18n.Errorf("ошибка итерации по регионам: %w", err)
+	}
+
+	return regions, nil
+}
+
+// SetBoundary stores the GeoJSON boundary of a region; nil removes it
+func (r *RegionRepository) SetBoundary(id int, boundary json.RawMessage) error {
+	var value any
+	if boundary != nil {
+		// lib/pq sends []byte as bytea, so the JSON is passed as text
+		value = string(boundary)
+	}
+
+	result, err := r.db.Exec(`UPDATE regions SET boundary = $2 WHERE id = $1`, id, value)
+	if err != nil {
+		return i18n.Errorf("ошибка сохранения границы региона: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return i18n.Errorf("регион с ID %d не найден", id)
+	}
+	return nil
+}
+
+// GetHerbs retrieves the herbs linked directly to each of the given regions
+func (r *RegionRepository) GetHerbs(regionIDs []int) (map[int][]models.Herb, error) {
+	// herbColumns are not qualified, so the link columns are renamed in a subquery
+	query := `
+		SELECT ` + herbColumns + `, links.link_region_id
+		FROM herbs
+		JOIN (
+			SELECT herb_id AS link_herb_id, region_id AS link_region_id
+			FROM herbs_regions
+			WHERE region_id = ANY($1)
+		) links ON links.link_herb_id = herbs.id
+		ORDER BY name, herbs.id`
+
+	rows, err := r.db.Query(query, intArray(regionIDs))
+	if err != nil {
+		return nil, i18n.Errorf("ошибка получения трав регионов: %w", err)
+	}
+	defer rows.Close()
+
+	herbs := make(map[int][]models.Herb)
+	for rows.Next() {
+		var (
+			herb     models.Herb
+			regionID int
+		)
+		if err := scanHerb(withExtra(rows, &regionID), &herb); err != nil {
+			return nil, i18n.Errorf("ошибка сканирования травы: %w", err)
+		}
+		herbs[regionID] = append(herbs[regionID], herb)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, i18n.Errorf("ошибка итерации по травам регионов: %w", err)
+	}
+
+	return herbs, nil
+}
+
+// Create adds a new region
+func (r *RegionRepository) Create(region *models.Region) error {
+	if err := region.Validate(); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO regions (name, description, parent_id)
+		VALUES ($1, NULLIF($2, ''), $3)
+		RETURNING id`
+
+	err := r.db.QueryRow(query, region.Name, region.Description, region.ParentID).Scan(&region.ID)
+	if err != nil {
+		return i18n.Errorf("ошибка создания региона: %w", err)
+	}
+	return nil
+}
+
+// SetParent moves a region under parentID, or makes it a top-level region if
+// parentID is nil. A region cannot be moved under itself or its sub-regions.
+func (r *RegionRepository) SetParent(id int, parentID *int) error {
+	if parentID != nil {
+		var cycle bool
+		query := `SELECT EXISTS (` + fmt.Sprintf(regionSubtree, "id = $1") + ` WHERE id = $2)`
+		if err := r.db.QueryRow(query, id, *parentID).Scan(&cycle); err != nil {
+			return i18n.Errorf("ошибка получения подрегионов: %w", err)
+		}
+		if cycle {
+			return i18n.Errorf("регион нельзя вложить в него самого или в его подрегион")
+		}
+	}
+
+	result, err := r.db.Exec(`UPDATE regions SET parent_id = $2 WHERE id = $1`, id, parentID)
+	if err != nil {
+		if isCheckViolation(err, "regions_no_cycle") {
+			return i18n.Errorf("регион нельзя вложить в него самого или в его подрегион")
+		}
+		return i18n.Errorf("ошибка изменения родительского региона: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return i18n.Errorf("регион с ID %d не найден", id)
+	}
+	return nil
+}
+
+// GetByHerb retrieves the regions where a herb grows
+func (r *RegionRepository) GetByHerb(herbID int) ([]models.Region, error) {
+	query := `
+		SELECT ` + regionColumns + `
+		FROM regions r
+		JOIN herbs_regions hr ON hr.region_id = r.id
+		WHERE hr.herb_id = $1
+		ORDER BY r.name`
+
+	rows, err := r.db.Query(query, herbID)
+	if err != nil {
+		return nil, i18n.Errorf("ошибка получения регионов травы: %w", err)
+	}
+	defer rows.Close()
+
+	return scanRegions(rows)
+}
+
+// LinkHerb records that a herb grows in a region
+func (r *RegionRepository) LinkHerb(herbID, regionID int) error {
+	query := `
+		INSERT INTO herbs_regions (herb_id, region_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING`
+
+	if _, err := r.db.Exec(query, herbID, regionID); err != nil {
+		return i18n.Errorf("ошибка привязки травы к региону: %w", err)
+	}
+	return nil
+}
+
+// GetByName retrieves a region by its name (case-insensitive)
+func (r *RegionRepository) GetByName(name string) (*models.Region, error) {
+	query := `
+		SELECT ` + regionColumns + `
+		FROM regions r
+		WHERE LOWER(r.name) = LOWER($1)
+		ORDER BY r.id`
+
+	rows, err := r.db.Query(query, name)
+	if err != nil {
+		return nil, i18n.Errorf("ошибка получения региона: %w", err)
+	}
+	defer rows.Close()
+
+	regions, err := scanRegions(rows)
+	if err != nil {
+		return nil, err
+	}
+	switch len(regions) {
+	case 0:
+		return nil, i18n.Errorf("регион «%s» не найден", name)
+	case 1:
+		return &regions[0], nil
+	}
+	return nil, i18n.Errorf("найдено несколько регионов с названием «%s»", name)
+}
+
+// SetHerbSeason links a herb to a region with the months of flowering and
+// harvest in that region. Nil months keep the months already set for the region;
+// where none are set, the months of the herb apply. inheritFlowering and
+// inheritHarvest remove the months set for the region instead. The link is
+// updated with the months stored for the region.
+func (r *RegionRepository) SetHerbSeason(link *models.HerbRegion, inheritFlowering, inheritHarvest bool) error {
+	if !link.FloweringMonths.Valid() || !link.HarvestMonths.Valid() {
+		return i18n.Errorf("месяцы должны быть от 1 до 12")
+	}
+
+	query := `
+		INSERT INTO herbs_regions (herb_id, region_id, flowering_months, harvest_months)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (herb_id, region_id) DO UPDATE
+		SET flowering_months = CASE WHEN $5 THEN NULL
+				ELSE COALESCE(EXCLUDED.flowering_months, herbs_regions.flowering_months) END,
+			harvest_months = CASE WHEN $6 THEN NULL
+				ELSE COALESCE(EXCLUDED.harvest_months, herbs_regions.harvest_months) END
+		RETURNING flowering_months, harvest_months`
+
+	if inheritFlowering {
+		link.FloweringMonths = nil
+	}
+	if inheritHarvest {
+		link.HarvestMonths = nil
+	}
+	err := r.db.QueryRow(query, link.HerbID, link.RegionID,
+		nullMonths(link.FloweringMonths), nullMonths(link.HarvestMonths),
+		inheritFlowering, inheritHarvest).
+		Scan(monthArray(&link.FloweringMonths), monthArray(&link.HarvestMonths))
+	if err != nil {
+		return i18n.Errorf("ошибка привязки травы к региону: %w", err)
+	}
+	return nil
+}
+
+// nullMonths stores nil months as NULL and other months as a sorted array
+func nullMonths(months models.Months) any {
+	if months == nil {
+		return nil
+	}
+	return intArray(months.Normalize())
+}
+
+// scanRegion reads a single region selected with regionColumns
+func scanRegion(row rowScanner, region *models.Region) error {
+	return row.Scan(&region.ID, &region.Name, &region.Description, &region.ParentID)
+}
+
+func scanRegions(rows *sql.Rows) ([]models.Region, error) {
+	var regions []models.Region
+	for rows.Next() {
+		region := models.Region{}
+		if err := scanRegion(rows, &region); err != nil {
+			return nil, i18n.Errorf("ошибка сканирования региона: %w", err)
+		}
+		regions = append(regions, region)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, i18n.Errorf("ошибка итерации по регионам: %w", err)
+	}
+
+	return regions, nil
+}
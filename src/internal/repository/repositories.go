@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"github.com/gloowl/simple_crud/src/internal/models"
+)
+
+// Repositories groups repositories bound to the same connection or transaction
+type Repositories struct {
+	Herbs      *HerbRepository
+	Regions    *RegionRepository
+	UsageTypes *UsageTypeRepository
+	Usages     *UsageRepository
+	Taxa       *TaxonRepository
+	Names      *HerbNameRepository
+	LookAlikes *LookAlikeRepository
+
+	Contraindications *ContraindicationRepository
+	Interactions      *InteractionRepository
+	Sources           *SourceRepository
+	Citations         *CitationRepository
+	Calendar          *CalendarRepository
+}
+
+// NewRepositories creates all repositories on top of db
+func NewRepositories(db DBTX) *Repositories {
+	return &Repositories{
+		Herbs:      NewHerbRepository(db),
+		Regions:    NewRegionRepository(db),
+		UsageTypes: NewUsageTypeRepository(db),
+		Usages:     NewUsageRepository(db),
+		Taxa:       NewTaxonRepository(db),
+		Names:      NewHerbNameRepository(db),
+		LookAlikes: NewLookAlikeRepository(db),
+
+		Contraindications: NewContraindicationRepository(db),
+		Interactions:      NewInteractionRepository(db),
+		Sources:           NewSourceRepository(db),
+		Citations:         NewCitationRepository(db),
+		Calendar:          NewCalendarRepository(db),
+	}
+}
+
+// HerbDetails retrieves a herb together with its regions, usages and alternative names
+func (r *Repositories) HerbDetails(id int) (*models.HerbWithDetails, error) {
+	herb, err := r.Herbs.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	regions, err := r.Regions.GetByHerb(id)
+	if err != nil {
+		return nil, err
+	}
+
+	usages, err := r.Usages.GetByHerb(id)
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := r.Names.GetByHerb(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.HerbWithDetails{Herb: *herb, Regions: regions, Usages: usages, Names: names}, nil
+}
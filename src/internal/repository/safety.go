@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+	"github.com/gloowl/simple_crud/src/internal/models"
+)
+
+// termMatch is an SQL condition that matches the column %[1]s against the
+// requested term %[2]s. Both are compared case-insensitively with whitespace
+// normalized, and either may contain the other, so "варфарин" finds
+// "варфарин и другие кумарины" and "сильные антикоагулянты" finds "антикоагулянты".
+const termMatch = `(STRPOS(LOWER(normalize_space(%[1]s)), LOWER(normalize_space(%[2]s))) > 0
+	OR STRPOS(LOWER(normalize_space(%[2]s)), LOWER(normalize_space(%[1]s))) > 0)`
+
+// unknownTerms returns the terms that match no row of table in column, in their original order
+func unknownTerms(db DBTX, table, column string, terms []string) ([]string, error) {
+	query := `
+		SELECT u.term
+		FROM UNNEST($1::text[]) WITH ORDINALITY AS u(term, n)
+		WHERE NOT EXISTS (SELECT 1 FROM ` + table + ` t WHERE ` + fmt.Sprintf(termMatch, "t."+column, "u.term") + `)
+		ORDER BY u.n`
+
+	rows, err := db.Query(query, textArray(terms))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var unknown []string
+	for rows.Next() {
+		var term string
+		if err := rows.Scan(&term); err != nil {
+			return nil, err
+		}
+		unknown = append(unknown, term)
+	}
+	return unknown, rows.Err()
+}
+
+// deleteByID removes a single row from table and fails if it does not exist
+func deleteByID(db DBTX, table string, id int, notFound error) error {
+	result, err := db.Exec(`DELETE FROM `+table+` WHERE id = $1`, id)
+	if err != nil {
+		return i18n.Errorf("ошибка удаления записи: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return i18n.Errorf("ошибка получения количества затронутых строк: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return notFound
+	}
+
+	return nil
+}
+
+type ContraindicationRepository struct {
+	db DBTX
+}
+
+func NewContraindicationRepository(db DBTX) *ContraindicationRepository {
+	return &ContraindicationRepository{db: db}
+}
+
+// Create adds a contraindication of a herb
+func (r *ContraindicationRepository) Create(c *models.Contraindication) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO contraindications (herb_id, condition, severity, notes)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`
+
+	err := r.db.QueryRow(query, c.HerbID, c.Condition, c.Severity, c.Notes).Scan(&c.ID)
+	if err != nil {
+		if isUniqueViolation(err, "contraindications_herb_condition") {
+			return i18n.Errorf("противопоказание «%s» для этой травы уже указано", c.Condition)
+		}
+		return i18n.Errorf("ошибка создания противопоказания: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a contraindication by its ID
+func (r *ContraindicationRepository) Delete(id int) error {
+	return deleteByID(r.db, "contraindications", id, i18n.Errorf("противопоказание с ID %d не найдено", id))
+}
+
+// Find retrieves the contraindications of the given herbs, the most serious first.
+// When conditions is not empty, only the contraindications whose condition
+// contains one of them or is contained in one (case-insensitive) are returned.
+func (r *ContraindicationRepository) Find(herbIDs []int, conditions []string) ([]models.Contraindication, error) {
+	query := `
+		SELECT c.id, c.herb_id, c.condition, c.severity, c.notes, h.name
+		FROM contraindications c
+		JOIN herbs h ON h.id = c.herb_id
+		WHERE c.herb_id = ANY($1)
+		  AND (CARDINALITY($2::text[]) = 0
+		       OR EXISTS (SELECT 1 FROM UNNEST($2::text[]) AS u(term) WHERE ` + fmt.Sprintf(termMatch, "c.condition", "u.term") + `))
+		ORDER BY c.severity DESC, h.name, c.condition`
+
+	rows, err := r.db.Query(query, intArray(herbIDs), textArray(conditions))
+	if err != nil {
+		return nil, i18n.Errorf("ошибка получения противопоказаний: %w", err)
+	}
+	defer rows.Close()
+
+	var contraindications []models.Contraindication
+	for rows.Next() {
+		c := models.Contraindication{}
+		if err := rows.Scan(&c.ID, &c.HerbID, &c.Condition, &c.Severity, &c.Notes, &c.HerbName); err != nil {
+			return nil, i18n.Errorf("ошибка сканирования противопоказания: %w", err)
+		}
+		contraindications = append(contraindications, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, i18n.Errorf("ошибка итерации по противопоказаниям: %w", err)
+	}
+
+	return contraindications, nil
+}
+
+// Unknown returns the conditions that match no contraindication of any herb,
+// so nothing is known about them
+func (r *ContraindicationRepository) Unknown(conditions []string) ([]string, error) {
+	unknown, err := unknownTerms(r.db, "contraindications", "condition", conditions)
+	if err != nil {
+		return nil, i18n.Errorf("ошибка получения противопоказаний: %w", err)
+	}
+	return unknown, nil
+}
+
+type InteractionRepository struct {
+	db DBTX
+}
+
+func NewInteractionRepository(db DBTX) *InteractionRepository {
+	return &InteractionRepository{db: db}
+}
+
+// Create adds a known interaction of a herb with a drug or drug class
+func (r *InteractionRepository) Create(i *models.Interaction) error {
+	if err := i.Validate(); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO interactions (herb_id, drug, severity, effect)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`
+
+	err := r.db.QueryRow(query, i.HerbID, i.Drug, i.Severity, i.Effect).Scan(&i.ID)
+	if err != nil {
+		if isUniqueViolation(err, "interactions_herb_drug") {
+			return i18n.Errorf("взаимодействие с «%s» для этой травы уже указано", i.Drug)
+		}
+		return i18n.Errorf("ошибка создания взаимодействия: %w", err)
+	}
+	return nil
+}
+
+// Delete removes an interaction by its ID
+func (r *InteractionRepository) Delete(id int) error {
+	return deleteByID(r.db, "interactions", id, i18n.Errorf("взаимодействие с ID %d не найдено", id))
+}
+
+// Find retrieves the interactions of the given herbs, the most serious first.
+// When drugs is not empty, only the interactions with drugs or drug classes that
+// contain one of them or are contained in one (case-insensitive) are returned.
+func (r *InteractionRepository) Find(herbIDs []int, drugs []string) ([]models.Interaction, error) {
+	query := `
+		SELECT i.id, i.herb_id, i.drug, i.severity, i.effect, h.name
+		FROM interactions i
+		JOIN herbs h ON h.id = i.herb_id
+		WHERE i.herb_id = ANY($1)
+		  AND (CARDINALITY($2::text[]) = 0
+		       OR EXISTS (SELECT 1 FROM UNNEST($2::text[]) AS u(term) WHERE ` + fmt.Sprintf(termMatch, "i.drug", "u.term") + `))
+		ORDER BY i.severity DESC, h.name, i.drug`
+
+	rows, err := r.db.Query(query, intArray(herbIDs), textArray(drugs))
+	if err != nil {
+		return nil, i18n.Errorf("ошибка получения взаимодействий: %w", err)
+	}
+	defer rows.Close()
+
+	var interactions []models.Interaction
+	for rows.Next() {
+		i := models.Interaction{}
+		if err := rows.Scan(&i.ID, &i.HerbID, &i.Drug, &i.Severity, &i.Effect, &i.HerbName); err != nil {
+			return nil, i18n.Errorf("ошибка сканирования взаимодействия: %w", err)
+		}
+		interactions = append(interactions, i)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, i18n.Errorf("ошибка итерации по взаимодействиям: %w", err)
+	}
+
+	return interactions, nil
+}
+
+// Unknown returns the drugs that match no interaction of any herb,
+// so nothing is known about them
+func (r *InteractionRepository) Unknown(drugs []string) ([]string, error) {
+	unknown, err := unknownTerms(r.db, "interactions", "drug", drugs)
+	if err != nil {
+		return nil, i18n.Errorf("ошибка получения взаимодействий: %w", err)
+	}
+	return unknown, nil
+}
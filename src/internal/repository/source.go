@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+	"github.com/gloowl/simple_crud/src/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// sourceColumns are the columns read by scanSource, prefixed with the sources alias s
+const sourceColumns = `s.id, s.kind, s.title, s.authors, COALESCE(s.year, 0), s.container,
+	s.publisher, s.volume, s.issue, s.pages, s.doi, s.isbn, s.url`
+
+// scanSource reads a single source selected with sourceColumns
+func scanSource(row rowScanner, source *models.Source) error {
+	return row.Scan(&source.ID, &source.Kind, &source.Title, pq.Array(&source.Authors), &source.Year,
+		&source.Container, &source.Publisher, &source.Volume, &source.Issue, &source.Pages,
+		&source.DOI, &source.ISBN, &source.URL)
+}
+
+type SourceRepository struct {
+	db DBTX
+}
+
+func NewSourceRepository(db DBTX) *SourceRepository {
+	return &SourceRepository{db: db}
+}
+
+// sourceConflict translates a unique violation on DOI or ISBN into a readable error
+func sourceConflict(err error, source *models.Source) error {
+	switch {
+	case isUniqueViolation(err, "sources_doi"):
+		return i18n.Errorf("источник с DOI %s уже есть", source.DOI)
+	case isUniqueViolation(err, "sources_isbn"):
+		return i18n.Errorf("источник с ISBN %s уже есть", source.ISBN)
+	}
+	return nil
+}
+
+// Create adds a new source
+func (r *SourceRepository) Create(source *models.Source) error {
+	source.Normalize()
+	if err := source.Validate(); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO sources (kind, title, authors, year, container, publisher,
+			volume, issue, pages, doi, isbn, url)
+		VALUES ($1, $2, $3, NULLIF($4, 0), $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING id`
+
+	err := r.db.QueryRow(query, source.Kind, source.Title, textArray(source.Authors), source.Year,
+		source.Container, source.Publisher, source.Volume, source.Issue, source.Pages,
+		source.DOI, source.ISBN, source.URL).Scan(&source.ID)
+	if err != nil {
+		if conflict := sourceConflict(err, source); conflict != nil {
+			return conflict
+		}
+		return i18n.Errorf("ошибка создания источника: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a source by its ID
+func (r *SourceRepository) GetByID(id int) (*models.Source, error) {
+	source := &models.Source{}
+	query := `SELECT ` + sourceColumns + ` FROM sources s WHERE s.id = $1`
+
+	if err := scanSource(r.db.QueryRow(query, id), source); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, i18n.Errorf("источник с ID %d не найден", id)
+		}
+		return nil, i18n.Errorf("ошибка получения источника: %w", err)
+	}
+	return source, nil
+}
+
+// GetAll retrieves all sources ordered by title
+func (r *SourceRepository) GetAll() ([]models.Source, error) {
+	return r.query(`SELECT ` + sourceColumns + ` FROM sources s ORDER BY s.title, s.id`)
+}
+
+// GetByHerbs retrieves the distinct sources cited for any of the given herbs
+func (r *SourceRepository) GetByHerbs(herbIDs []int) ([]models.Source, error) {
+	query := `
+		SELECT ` + sourceColumns + `
+		FROM sources s
+		WHERE EXISTS (SELECT 1 FROM citations c WHERE c.source_id = s.id AND c.herb_id = ANY($1))
+		ORDER BY s.title, s.id`
+	return r.query(query, intArray(herbIDs))
+}
+
+func (r *SourceRepository) query(query string, args ...any) ([]models.Source, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, i18n.Errorf("ошибка получения источников: %w", err)
+	}
+	defer rows.Close()
+
+	var sources []models.Source
+	for rows.Next() {
+		source := models.Source{}
+		if err := scanSource(rows, &source); err != nil {
+			return nil, i18n.Errorf("ошибка сканирования источника: %w", err)
+		}
+		sources = append(sources, source)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, i18n.Errorf("ошибка итерации по источникам: %w", err)
+	}
+
+	return sources, nil
+}
+
+// Delete removes a source together with all citations of it
+func (r *SourceRepository) Delete(id int) error {
+	return deleteByID(r.db, "sources", id, i18n.Errorf("источник с ID %d не найден", id))
+}
+
+type CitationRepository struct {
+	db DBTX
+}
+
+func NewCitationRepository(db DBTX) *CitationRepository {
+	return &CitationRepository{db: db}
+}
+
+// Create cites a source for a fact about a herb. A usage must belong to the herb.
+func (r *CitationRepository) Create(citation *models.Citation) error {
+	if err := citation.Validate(); err != nil {
+		return err
+	}
+
+	if citation.UsageID != nil {
+		var herbID int
+		err := r.db.QueryRow(`SELECT herb_id FROM usages WHERE id = $1`, *citation.UsageID).Scan(&herbID)
+		if err == sql.ErrNoRows || (err == nil && herbID != citation.HerbID) {
+			return i18n.Errorf("у травы нет способа применения с ID %d", *citation.UsageID)
+		}
+		if err != nil {
+			return i18n.Errorf("ошибка получения способа применения: %w", err)
+		}
+	}
+
+	query := `
+		INSERT INTO citations (source_id, herb_id, fact, usage_id, pages)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`
+
+	err := r.db.QueryRow(query, citation.SourceID, citation.HerbID, citation.Fact,
+		citation.UsageID, citation.Pages).Scan(&citation.ID)
+	if err != nil {
+		if isUniqueViolation(err, "citations_fact_source") {
+			return i18n.Errorf("этот источник уже указан для этих сведений")
+		}
+		return i18n.Errorf("ошибка добавления ссылки на источник: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a citation by its ID
+func (r *CitationRepository) Delete(id int) error {
+	return deleteByID(r.db, "citations", id, i18n.Errorf("ссылка на источник с ID %d не найдена", id))
+}
+
+// GetByHerb retrieves the citations of a herb together with their sources,
+// ordered by fact: the herb itself, its toxicity, then its usages
+func (r *CitationRepository) GetByHerb(herbID int) ([]models.Citation, error) {
+	query := `
+		SELECT ` + sourceColumns + `, c.id, c.source_id, c.herb_id, c.fact, c.usage_id, c.pages
+		FROM citations c
+		JOIN sources s ON s.id = c.source_id
+		WHERE c.herb_id = $1
+		ORDER BY CASE c.fact WHEN 'herb' THEN 0 WHEN 'toxicity' THEN 1 ELSE 2 END,
+			c.usage_id NULLS FIRST, s.title, c.id`
+
+	rows, err := r.db.Query(query, herbID)
+	if err != nil {
+		return nil, i18n.Errorf("ошибка получения ссылок на источники: %w", err)
+	}
+	defer rows.Close()
+
+	var citations []models.Citation
+	for rows.Next() {
+		citation := models.Citation{Source: &models.Source{}}
+		err := scanSource(withExtra(rows, &citation.ID, &citation.SourceID, &citation.HerbID,
+			&citation.Fact, &citation.UsageID, &citation.Pages), citation.Source)
+		if err != nil {
+			return nil, i18n.Errorf("ошибка сканирования ссылки на источник: %w", err)
+		}
+		citations = append(citations, citation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, i18n.Errorf("ошибка итерации по ссылкам на источники: %w", err)
+	}
+
+	return citations, nil
+}
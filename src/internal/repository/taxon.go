@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+	"github.com/gloowl/simple_crud/src/internal/models"
+)
+
+// taxonSubtree selects the IDs of a taxon and all of its descendants;
+// the condition on the root taxon is substituted with fmt.Sprintf
+const taxonSubtree = `
+		WITH RECURSIVE subtree AS (
+			SELECT id FROM taxa WHERE %s
+			UNION ALL
+			SELECT t.id FROM taxa t JOIN subtree s ON t.parent_id = s.id
+		)
+		SELECT id FROM subtree`
+
+type TaxonRepository struct {
+	db DBTX
+}
+
+func NewTaxonRepository(db DBTX) *TaxonRepository {
+	return &TaxonRepository{db: db}
+}
+
+// GetAll retrieves all taxa together with the number of herbs linked directly to each
+func (r *TaxonRepository) GetAll() ([]models.Taxon, error) {
+	query := `
+		SELECT t.id, t.rank, t.name, t.parent_id, COUNT(h.id)
+		FROM taxa t
+		LEFT JOIN herbs h ON h.taxon_id = t.id
+		GROUP BY t.id
+		ORDER BY t.name`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, i18n.Errorf("ошибка получения таксонов: %w", err)
+	}
+	defer rows.Close()
+
+	var taxa []models.Taxon
+	for rows.Next() {
+		taxon := models.Taxon{}
+		if err := rows.Scan(&taxon.ID, &taxon.Rank, &taxon.Name, &taxon.ParentID, &taxon.HerbCount); err != nil {
+			return nil, i18n.Errorf("ошибка сканирования таксона: %w", err)
+		}
+		taxa = append(taxa, taxon)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, i18n.Errorf("ошибка итерации по таксонам: %w", err)
+	}
+
+	return taxa, nil
+}
+
+// GetByName retrieves the taxon of the given rank by its name (case-insensitive)
+func (r *TaxonRepository) GetByName(rank, name string) (*models.Taxon, error) {
+	taxon := &models.Taxon{}
+	query := `
+		SELECT id, rank, name, parent_id
+		FROM taxa
+		WHERE rank = $1 AND LOWER(name) = LOWER($2)`
+
+	err := r.db.QueryRow(query, rank, name).Scan(&taxon.ID, &taxon.Rank, &taxon.Name, &taxon.ParentID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, i18n.Errorf("не найден таксон «%s» (%s)", name, models.RankLabel(rank))
+		}
+		return nil, i18n.Errorf("ошибка получения таксона: %w", err)
+	}
+	return taxon, nil
+}
+
+// Save creates the taxon, or moves an existing taxon with the same rank and name
+// under the new parent. An existing parent is kept when ParentID is nil.
+func (r *TaxonRepository) Save(taxon *models.Taxon) error {
+	if err := taxon.Validate(); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO taxa (rank, name, parent_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (rank, LOWER(name)) DO UPDATE SET parent_id = COALESCE(EXCLUDED.parent_id, taxa.parent_id)
+		RETURNING id, name`
+
+	err := r.db.QueryRow(query, taxon.Rank, taxon.Name, taxon.ParentID).Scan(&taxon.ID, &taxon.Name)
+	if err != nil {
+		return i18n.Errorf("ошибка сохранения таксона: %w", err)
+	}
+	return nil
+}
+
+// ensure returns the ID of the taxon with the given rank and name, creating it
+// under parentID if it does not exist yet. The parent of an existing taxon is kept.
+func (r *TaxonRepository) ensure(rank, name string, parentID *int) (int, error) {
+	query := `
+		WITH inserted AS (
+			INSERT INTO taxa (rank, name, parent_id)
+			VALUES ($1, $2, $3)
+			ON CONFLICT DO NOTHING
+			RETURNING id
+		)
+		SELECT id FROM inserted
+		UNION ALL
+		SELECT id FROM taxa WHERE rank = $1 AND LOWER(name) = LOWER($2)
+		LIMIT 1`
+
+	var id int
+	if err := r.db.QueryRow(query, rank, name, parentID).Scan(&id); err != nil {
+		return 0, i18n.Errorf("ошибка сохранения таксона: %w", err)
+	}
+	return id, nil
+}
+
+// LinkHerb links the herb to the species (or, without a specific epithet, the genus)
+// of its parsed latin name, creating the taxa when needed. A herb without
+// a genus is unlinked.
+func (r *TaxonRepository) LinkHerb(herb *models.Herb) error {
+	var taxonID *int
+
+	if herb.Genus != "" {
+		genusID, err := r.ensure(models.RankGenus, herb.Genus, nil)
+		if err != nil {
+			return err
+		}
+		taxonID = &genusID
+
+		if species := models.SpeciesName(herb); species != "" {
+			speciesID, err := r.ensure(models.RankSpecies, species, &genusID)
+			if err != nil {
+				return err
+			}
+			taxonID = &speciesID
+		}
+	}
+
+	if _, err := r.db.Exec(`UPDATE herbs SET taxon_id = $2 WHERE id = $1`, herb.ID, taxonID); err != nil {
+		return i18n.Errorf("ошибка привязки травы к таксону: %w", err)
+	}
+	herb.TaxonID = taxonID
+	return nil
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+
+	"github.com/lib/pq"
+)
+
+// DBTX is implemented by both *sql.DB and *sql.Tx, so repositories can run
+// either directly on the connection pool or inside a transaction
+type DBTX interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// PostgreSQL error codes after which a transaction can simply be retried
+const (
+	serializationFailure = "40001"
+	deadlockDetected     = "40P01"
+)
+
+// defaultMaxAttempts is how many times a unit of work is tried before giving up
+const defaultMaxAttempts = 3
+
+// UnitOfWork runs a group of repository calls atomically
+type UnitOfWork struct {
+	db          *sql.DB
+	maxAttempts int
+}
+
+func NewUnitOfWork(db *sql.DB) *UnitOfWork {
+	return &UnitOfWork{db: db, maxAttempts: defaultMaxAttempts}
+}
+
+// Do runs fn in a serializable transaction and passes it repositories bound to
+// that transaction. The transaction is committed when fn returns nil and rolled
+// back otherwise. On serialization failures and deadlocks the whole unit of
+// work, including fn, is retried, so fn must not have side effects outside the database.
+func (u *UnitOfWork) Do(fn func(repos *Repositories) error) error {
+	var err error
+	for attempt := 1; attempt <= u.maxAttempts; attempt++ {
+		err = u.run(fn)
+		if err == nil || !isRetryable(err) {
+			return err
+		}
+		time.Sleep(time.Duration(attempt*attempt) * 10 * time.Millisecond)
+	}
+	return i18n.Errorf("транзакция не выполнена после %d попыток: %w", u.maxAttempts, err)
+}
+
+// run executes fn in a single transaction
+func (u *UnitOfWork) run(fn func(repos *Repositories) error) error {
+	tx, err := u.db.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return i18n.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(NewRepositories(tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return i18n.Errorf("ошибка фиксации транзакции: %w", err)
+	}
+	return nil
+}
+
+// isRetryable reports whether err was caused by a conflict between concurrent transactions
+func isRetryable(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	return pqErr.Code == serializationFailure || pqErr.Code == deadlockDetected
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+	"github.com/gloowl/simple_crud/src/internal/models"
+)
+
+type UsageTypeRepository struct {
+	db DBTX
+}
+
+func NewUsageTypeRepository(db DBTX) *UsageTypeRepository {
+	return &UsageTypeRepository{db: db}
+}
+
+// GetAll retrieves all usage types
+func (r *UsageTypeRepository) GetAll() ([]models.UsageType, error) {
+	rows, err := r.db.Query(`SELECT id, name FROM usage_types ORDER BY name`)
+	if err != nil {
+		return nil, i18n.Errorf("ошибка получения типов использования: %w", err)
+	}
+	defer rows.Close()
+
+	var usageTypes []models.UsageType
+	for rows.Next() {
+		usageType := models.UsageType{}
+		if err := rows.Scan(&usageType.ID, &usageType.Name); err != nil {
+			return nil, i18n.Errorf("ошибка сканирования типа использования: %w", err)
+		}
+		usageTypes = append(usageTypes, usageType)
+	}
+
+	return usageTypes, rows.Err()
+}
+
+type UsageRepository struct {
+	db DBTX
+}
+
+func NewUsageRepository(db DBTX) *UsageRepository {
+	return &UsageRepository{db: db}
+}
+
+// usageColumns are the columns read by the usage queries, in scanUsage order
+const usageColumns = `u.id, u.herb_id, u.usage_type_id, COALESCE(u.description, ''),
+	u.plant_part, u.preparation, COALESCE(u.amount, 0)::float8, u.unit,
+	COALESCE(u.times_per_day, 0), COALESCE(u.duration_days, 0), h.name, ut.name`
+
+func scanUsage(row rowScanner) (models.Usage, error) {
+	usage := models.Usage{}
+	err := row.Scan(&usage.ID, &usage.HerbID, &usage.UsageTypeID, &usage.Description,
+		&usage.PlantPart, &usage.Preparation, &usage.Amount, &usage.Unit,
+		&usage.TimesPerDay, &usage.DurationDays, &usage.HerbName, &usage.UsageTypeName)
+	return usage, err
+}
+
+// Create adds a new usage of a herb
+func (r *UsageRepository) Create(usage *models.Usage) error {
+	if err := usage.Validate(); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO usages (herb_id, usage_type_id, description, plant_part, preparation,
+			amount, unit, times_per_day, duration_days)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, 0), $7, NULLIF($8, 0), NULLIF($9, 0))
+		RETURNING id`
+
+	err := r.db.QueryRow(query, usage.HerbID, usage.UsageTypeID, usage.Description,
+		usage.PlantPart, usage.Preparation, usage.Amount, usage.Unit,
+		usage.TimesPerDay, usage.DurationDays).Scan(&usage.ID)
+	if err != nil {
+		return i18n.Errorf("ошибка создания способа применения: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a usage by its ID
+func (r *UsageRepository) Delete(id int) error {
+	return deleteByID(r.db, "usages", id, i18n.Errorf("способ применения с ID %d не найден", id))
+}
+
+// GetByHerb retrieves the usages of a herb together with their type names
+func (r *UsageRepository) GetByHerb(herbID int) ([]models.Usage, error) {
+	return r.List([]int{herbID})
+}
+
+// List retrieves the usages of the given herbs, or of all herbs if herbIDs is empty
+func (r *UsageRepository) List(herbIDs []int) ([]models.Usage, error) {
+	query := `
+		SELECT ` + usageColumns + `
+		FROM usages u
+		JOIN herbs h ON h.id = u.herb_id
+		JOIN usage_types ut ON ut.id = u.usage_type_id
+		WHERE CARDINALITY($1::int[]) = 0 OR u.herb_id = ANY($1)
+		ORDER BY h.name, u.herb_id, ut.name, u.id`
+
+	rows, err := r.db.Query(query, intArray(herbIDs))
+	if err != nil {
+		return nil, i18n.Errorf("ошибка получения способов применения: %w", err)
+	}
+	defer rows.Close()
+
+	var usages []models.Usage
+	for rows.Next() {
+		usage, err := scanUsage(rows)
+		if err != nil {
+			return nil, i18n.Errorf("ошибка сканирования способа применения: %w", err)
+		}
+		usages = append(usages, usage)
+	}
+
+	return usages, rows.Err()
+}
@@ -0,0 +1,231 @@
+package table
+
+import (
+	"io"
+	"strings"
+
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+)
+
+// Style selects how a table is drawn
+type Style int
+
+const (
+	// StylePlain separates columns with spaces and underlines the header
+	StylePlain Style = iota
+	// StyleMarkdown produces a GitHub-flavored Markdown table
+	StyleMarkdown
+	// StyleBox draws the table with box-drawing characters
+	StyleBox
+)
+
+// StyleNames lists the accepted style names in the order they are documented
+var StyleNames = []string{"plain", "markdown", "box"}
+
+// ParseStyle converts a style name into a Style
+func ParseStyle(name string) (Style, error) {
+	switch strings.ToLower(name) {
+	case "", "plain":
+		return StylePlain, nil
+	case "markdown", "md":
+		return StyleMarkdown, nil
+	case "box":
+		return StyleBox, nil
+	}
+	return StylePlain, i18n.Errorf("неизвестный стиль таблицы: %s (доступно: %s)", name, strings.Join(StyleNames, ", "))
+}
+
+// minColumnWidth is the narrowest a column is shrunk to when fitting the table
+const minColumnWidth = 3
+
+// Column describes a table column
+type Column struct {
+	Title      string
+	MaxWidth   int // 0 means unlimited
+	AlignRight bool
+}
+
+// Table is a set of rows rendered with column widths measured in terminal columns
+type Table struct {
+	Columns []Column
+	Rows    [][]string
+	Style   Style
+	// MaxWidth is the total width the table has to fit into; 0 means unlimited
+	MaxWidth int
+}
+
+// AddRow appends a row; missing cells are left empty
+func (t *Table) AddRow(cells ...string) {
+	t.Rows = append(t.Rows, cells)
+}
+
+// Render writes the table to w
+func (t *Table) Render(w io.Writer) error {
+	widths := t.columnWidths()
+
+	var b strings.Builder
+	switch t.Style {
+	case StyleMarkdown:
+		t.renderMarkdown(&b, widths)
+	case StyleBox:
+		t.renderBox(&b, widths)
+	default:
+		t.renderPlain(&b, widths)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// columnWidths measures the columns and shrinks the widest ones until the
+// table fits into MaxWidth
+func (t *Table) columnWidths() []int {
+	widths := make([]int, len(t.Columns))
+	for i, column := range t.Columns {
+		widths[i] = Width(column.Title)
+		for _, row := range t.Rows {
+			if i < len(row) {
+				widths[i] = max(widths[i], Width(t.cell(row, i)))
+			}
+		}
+		if column.MaxWidth > 0 {
+			widths[i] = min(widths[i], column.MaxWidth)
+		}
+		if t.Style == StyleMarkdown {
+			// The delimiter row needs at least three characters
+			widths[i] = max(widths[i], minColumnWidth)
+		}
+	}
+
+	if t.MaxWidth <= 0 {
+		return widths
+	}
+
+	for t.totalWidth(widths) > t.MaxWidth {
+		widest := 0
+		for i := range widths {
+			if widths[i] > widths[widest] {
+				widest = i
+			}
+		}
+		if widths[widest] <= minColumnWidth {
+			break
+		}
+		widths[widest]--
+	}
+	return widths
+}
+
+// totalWidth returns the width of a rendered line for the given column widths
+func (t *Table) totalWidth(widths []int) int {
+	total := 0
+	for _, w := range widths {
+		total += w
+	}
+
+	n := len(widths)
+	switch t.Style {
+	case StyleMarkdown, StyleBox:
+		return total + 3*n + 1 // "| " before each cell, " " after it and the closing "|"
+	default:
+		return total + 2*max(n-1, 0)
+	}
+}
+
+// cell returns the text of a cell with line breaks flattened
+func (t *Table) cell(row []string, i int) string {
+	if i >= len(row) {
+		return ""
+	}
+	value := strings.Join(strings.Fields(row[i]), " ")
+	if t.Style == StyleMarkdown {
+		value = strings.ReplaceAll(value, "|", `\|`)
+	}
+	return value
+}
+
+// format fits a cell value into its column
+func (t *Table) format(value string, i, width int) string {
+	value = Truncate(value, width)
+	if t.Columns[i].AlignRight {
+		return PadLeft(value, width)
+	}
+	return Pad(value, width)
+}
+
+func (t *Table) titles() []string {
+	titles := make([]string, len(t.Columns))
+	for i, column := range t.Columns {
+		titles[i] = column.Title
+	}
+	return titles
+}
+
+func (t *Table) renderPlain(b *strings.Builder, widths []int) {
+	line := func(cells []string) {
+		parts := make([]string, len(widths))
+		for i, w := range widths {
+			parts[i] = t.format(t.cell(cells, i), i, w)
+		}
+		b.WriteString(strings.TrimRight(strings.Join(parts, "  "), " ") + "\n")
+	}
+
+	line(t.titles())
+	rule := make([]string, len(widths))
+	for i, w := range widths {
+		rule[i] = strings.Repeat("-", w)
+	}
+	b.WriteString(strings.Join(rule, "  ") + "\n")
+	for _, row := range t.Rows {
+		line(row)
+	}
+}
+
+func (t *Table) renderMarkdown(b *strings.Builder, widths []int) {
+	line := func(cells []string) {
+		b.WriteString("|")
+		for i, w := range widths {
+			b.WriteString(" " + t.format(t.cell(cells, i), i, w) + " |")
+		}
+		b.WriteString("\n")
+	}
+
+	line(t.titles())
+	b.WriteString("|")
+	for i, w := range widths {
+		dashes := strings.Repeat("-", w)
+		if t.Columns[i].AlignRight {
+			dashes = dashes[1:] + ":"
+		}
+		b.WriteString(" " + dashes + " |")
+	}
+	b.WriteString("\n")
+	for _, row := range t.Rows {
+		line(row)
+	}
+}
+
+func (t *Table) renderBox(b *strings.Builder, widths []int) {
+	border := func(left, middle, right string) {
+		parts := make([]string, len(widths))
+		for i, w := range widths {
+			parts[i] = strings.Repeat("─", w+2)
+		}
+		b.WriteString(left + strings.Join(parts, middle) + right + "\n")
+	}
+	line := func(cells []string) {
+		b.WriteString("│")
+		for i, w := range widths {
+			b.WriteString(" " + t.format(t.cell(cells, i), i, w) + " │")
+		}
+		b.WriteString("\n")
+	}
+
+	border("┌", "┬", "┐")
+	line(t.titles())
+	border("├", "┼", "┤")
+	for _, row := range t.Rows {
+		line(row)
+	}
+	border("└", "┴", "┘")
+}
@@ -0,0 +1,178 @@
+package table
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/width"
+)
+
+// ellipsis marks truncated text
+const ellipsis = "…"
+
+// Emoji presentation selector: the preceding character is drawn as a two-column emoji
+const emojiPresentation = '\uFE0F'
+
+// RuneWidth returns the number of terminal columns taken by r on its own
+func RuneWidth(r rune) int {
+	switch {
+	case r == 0:
+		return 0
+	case r < 0x20 || (r >= 0x7f && r < 0xa0):
+		return 0
+	case isZeroWidth(r):
+		return 0
+	case isEmoji(r):
+		return 2
+	}
+
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	}
+	return 1
+}
+
+// isZeroWidth reports whether r combines with the preceding character
+func isZeroWidth(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) ||
+		(r >= 0xFE00 && r <= 0xFE0F) || r == 0x200D
+}
+
+// isEmoji reports whether r is drawn as an emoji even without a presentation selector
+func isEmoji(r rune) bool {
+	return (r >= 0x1F300 && r <= 0x1F64F) || (r >= 0x1F680 && r <= 0x1F6FF) ||
+		(r >= 0x1F900 && r <= 0x1FAFF) || r == 0x2705 || r == 0x274C
+}
+
+// Width returns the number of terminal columns taken by s
+func Width(s string) int {
+	total := 0
+	var prev rune
+	for _, r := range s {
+		if r == emojiPresentation && prev != 0 && RuneWidth(prev) == 1 {
+			total++ // the preceding character widens to an emoji
+		}
+		total += RuneWidth(r)
+		prev = r
+	}
+	return total
+}
+
+// Truncate shortens s to at most maxWidth columns, ending it with an ellipsis
+// if anything was cut. Characters are never split, and combining marks stay
+// with their base character.
+func Truncate(s string, maxWidth int) string {
+	if Width(s) <= maxWidth {
+		return s
+	}
+	if maxWidth <= 0 {
+		return ""
+	}
+
+	limit := maxWidth - Width(ellipsis)
+	var b strings.Builder
+	used := 0
+	clusters := splitClusters(s)
+	for _, cluster := range clusters {
+		w := Width(cluster)
+		if used+w > limit {
+			break
+		}
+		b.WriteString(cluster)
+		used += w
+	}
+	return strings.TrimRightFunc(b.String(), unicode.IsSpace) + ellipsis
+}
+
+// Pad appends spaces to s until it is width columns wide
+func Pad(s string, width int) string {
+	if gap := width - Width(s); gap > 0 {
+		return s + strings.Repeat(" ", gap)
+	}
+	return s
+}
+
+// PadLeft prepends spaces to s until it is width columns wide
+func PadLeft(s string, width int) string {
+	if gap := width - Width(s); gap > 0 {
+		return strings.Repeat(" ", gap) + s
+	}
+	return s
+}
+
+// Fit truncates or pads s to exactly width columns
+func Fit(s string, width int) string {
+	return Pad(Truncate(s, width), width)
+}
+
+// Wrap splits text into lines of at most maxWidth columns, breaking at spaces where possible
+func Wrap(text string, maxWidth int) []string {
+	if maxWidth <= 0 {
+		return nil
+	}
+
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			for Width(word) > maxWidth {
+				if line != "" {
+					lines = append(lines, line)
+					line = ""
+				}
+				head, rest := splitAtWidth(word, maxWidth)
+				lines = append(lines, head)
+				word = rest
+			}
+			switch {
+			case line == "":
+				line = word
+			case Width(line)+1+Width(word) <= maxWidth:
+				line += " " + word
+			default:
+				lines = append(lines, line)
+				line = word
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// splitAtWidth splits s after as many whole characters as fit into maxWidth columns
+func splitAtWidth(s string, maxWidth int) (string, string) {
+	used, offset := 0, 0
+	for _, cluster := range splitClusters(s) {
+		w := Width(cluster)
+		if used+w > maxWidth && offset > 0 {
+			break
+		}
+		used += w
+		offset += len(cluster)
+	}
+	return s[:offset], s[offset:]
+}
+
+// splitClusters splits s into base characters followed by their combining marks,
+// selectors and zero-width joined sequences
+func splitClusters(s string) []string {
+	var clusters []string
+	start := -1
+	joined := false
+	for i, r := range s {
+		if start >= 0 && (isZeroWidth(r) || joined) {
+			joined = r == 0x200D
+			continue
+		}
+		if start >= 0 {
+			clusters = append(clusters, s[start:i])
+		}
+		start = i
+		joined = false
+	}
+	if start >= 0 {
+		clusters = append(clusters, s[start:])
+	}
+	return clusters
+}
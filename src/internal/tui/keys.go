@@ -0,0 +1,125 @@
+package tui
+
+import (
+	"os"
+	"unicode/utf8"
+)
+
+// KeyCode identifies a key that is not a printable character
+type KeyCode int
+
+const (
+	KeyRune KeyCode = iota
+	KeyUp
+	KeyDown
+	KeyLeft
+	KeyRight
+	KeyPageUp
+	KeyPageDown
+	KeyHome
+	KeyEnd
+	KeyEnter
+	KeyBackspace
+	KeyEscape
+	KeyTab
+	KeyCtrlC
+	KeyUnknown
+)
+
+// Key is a single key press; Rune is set for KeyRune
+type Key struct {
+	Code KeyCode
+	Rune rune
+}
+
+// escapeSequences maps the input sequences sent by common terminals to keys
+var escapeSequences = map[string]KeyCode{
+	"\x1b[A":  KeyUp,
+	"\x1b[B":  KeyDown,
+	"\x1b[C":  KeyRight,
+	"\x1b[D":  KeyLeft,
+	"\x1bOA":  KeyUp,
+	"\x1bOB":  KeyDown,
+	"\x1bOC":  KeyRight,
+	"\x1bOD":  KeyLeft,
+	"\x1b[5~": KeyPageUp,
+	"\x1b[6~": KeyPageDown,
+	"\x1b[H":  KeyHome,
+	"\x1b[F":  KeyEnd,
+	"\x1bOH":  KeyHome,
+	"\x1bOF":  KeyEnd,
+	"\x1b[1~": KeyHome,
+	"\x1b[4~": KeyEnd,
+}
+
+// ReadKey waits for the next key press
+func (s *Screen) ReadKey() (Key, error) {
+	for len(s.keys) == 0 {
+		buf := make([]byte, 256)
+		n, err := os.Stdin.Read(buf)
+		if err != nil {
+			return Key{}, err
+		}
+		s.keys = parseKeys(buf[:n])
+	}
+
+	key := s.keys[0]
+	s.keys = s.keys[1:]
+	return key, nil
+}
+
+// parseKeys splits a chunk of raw terminal input into key presses
+func parseKeys(input []byte) []Key {
+	var keys []Key
+
+	for len(input) > 0 {
+		if input[0] == 0x1b {
+			if len(input) == 1 {
+				keys = append(keys, Key{Code: KeyEscape})
+				break
+			}
+			matched := false
+			for seq, code := range escapeSequences {
+				if len(input) >= len(seq) && string(input[:len(seq)]) == seq {
+					keys = append(keys, Key{Code: code})
+					input = input[len(seq):]
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				// An unknown sequence: skip it up to its final letter or tilde
+				end := 1
+				for end < len(input) && !(input[end] >= 0x40 && input[end] <= 0x7e && end > 1) {
+					end++
+				}
+				keys = append(keys, Key{Code: KeyUnknown})
+				input = input[min(end+1, len(input)):]
+			}
+			continue
+		}
+
+		switch input[0] {
+		case '\r', '\n':
+			keys = append(keys, Key{Code: KeyEnter})
+		case 0x7f, 0x08:
+			keys = append(keys, Key{Code: KeyBackspace})
+		case '\t':
+			keys = append(keys, Key{Code: KeyTab})
+		case 0x03:
+			keys = append(keys, Key{Code: KeyCtrlC})
+		default:
+			r, size := utf8.DecodeRune(input)
+			if r == utf8.RuneError || r < 0x20 {
+				keys = append(keys, Key{Code: KeyUnknown})
+			} else {
+				keys = append(keys, Key{Code: KeyRune, Rune: r})
+			}
+			input = input[size:]
+			continue
+		}
+		input = input[1:]
+	}
+
+	return keys
+}
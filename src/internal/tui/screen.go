@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+
+	"github.com/gloowl/simple_crud/src/internal/i18n"
+
+	"golang.org/x/term"
+)
+
+// ANSI escape sequences used for drawing
+const (
+	enterAltScreen = "\x1b[?1049h"
+	leaveAltScreen = "\x1b[?1049l"
+	hideCursor     = "\x1b[?25l"
+	showCursor     = "\x1b[?25h"
+
+	Reset   = "\x1b[0m"
+	Bold    = "\x1b[1m"
+	Reverse = "\x1b[7m"
+	Red     = "\x1b[31m"
+)
+
+// Screen is a full-screen terminal session in raw mode
+type Screen struct {
+	fd    int
+	state *term.State
+	out   *bufio.Writer
+	keys  []Key
+}
+
+// Open switches the terminal to raw mode and the alternate screen
+func Open() (*Screen, error) {
+	s := &Screen{fd: int(os.Stdin.Fd()), out: bufio.NewWriter(os.Stdout)}
+	if err := s.Resume(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Close restores the terminal to the state it had before Open
+func (s *Screen) Close() {
+	s.Suspend()
+}
+
+// Suspend temporarily gives the terminal back, e.g. to run an editor
+func (s *Screen) Suspend() {
+	s.out.WriteString(Reset + showCursor + leaveAltScreen)
+	s.out.Flush()
+	if s.state != nil {
+		term.Restore(s.fd, s.state)
+		s.state = nil
+	}
+}
+
+// Resume takes over the terminal again after Suspend
+func (s *Screen) Resume() error {
+	state, err := term.MakeRaw(s.fd)
+	if err != nil {
+		return i18n.Errorf("не удалось перевести терминал в полноэкранный режим: %v", err)
+	}
+	s.state = state
+	s.out.WriteString(enterAltScreen + hideCursor)
+	return s.out.Flush()
+}
+
+// Size returns the width and height of the terminal
+func (s *Screen) Size() (int, int) {
+	width, height, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || width <= 0 || height <= 0 {
+		return 80, 24
+	}
+	return width, height
+}
+
+// Line draws text at the given row (counted from 0), replacing the whole line
+func (s *Screen) Line(row int, text string) {
+	fmt.Fprintf(s.out, "\x1b[%d;1H%s%s\x1b[K", row+1, text, Reset)
+}
+
+// Flush sends everything drawn so far to the terminal
+func (s *Screen) Flush() error {
+	return s.out.Flush()
+}